@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/fatih/color"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var audioAssets *[]string
+var audioOutput *string
+
+func init() {
+	audioAssets = audioCmd.Flags().StringSliceP("assets", "a", []string{}, "Comma-separated list of asset paths to export. (supports glob)")
+	audioOutput = audioCmd.Flags().StringP("output", "o", "audio", "Output directory")
+
+	rootCmd.AddCommand(audioCmd)
+}
+
+var audioCmd = &cobra.Command{
+	Use:   "audio",
+	Short: "Export SoundWave assets as audio files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		color.NoColor = false
+
+		ctx := log.Logger.WithContext(cmd.Context())
+
+		return processPaks(ctx, matchAssets(*audioAssets), func(name string, entry *parser.PakEntrySet, pak *parser.PakFile) {
+			sounds := make([]parser.PakExportSet, 0)
+			for _, exportSet := range entry.Exports {
+				if exportSet.Data == nil {
+					continue
+				}
+
+				if _, ok := exportSet.Data.Data.(*parser.SoundWave); ok {
+					sounds = append(sounds, exportSet)
+				}
+			}
+
+			for _, exportSet := range sounds {
+				sound := exportSet.Data.Data.(*parser.SoundWave)
+
+				if _, data := sound.Payload(); data == nil {
+					log.Warn().Msgf("No audio payload available: %s", name)
+					continue
+				}
+
+				destination := filepath.Join(*audioOutput, strings.TrimSuffix(name, ".uexp"))
+				if len(sounds) > 1 {
+					destination += "_" + strings.Trim(exportSet.Export.ObjectName, "\x00")
+				}
+				destination += "." + sound.AudioExtension()
+
+				if err := writeAudio(destination, sound); err != nil {
+					log.Error().Err(err).Msgf("Failed writing audio: %s", destination)
+					continue
+				}
+
+				log.Info().Msgf("Wrote audio: %s", destination)
+			}
+		})
+	},
+}
+
+func writeAudio(destination string, sound *parser.SoundWave) error {
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return sound.WriteAudio(f)
+}
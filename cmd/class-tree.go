@@ -58,7 +58,7 @@ var classTreeCmd = &cobra.Command{
 
 			ctx := log.Logger.WithContext(cmd.Context())
 
-			p := parser.NewParser(file)
+			p := newParser(file)
 			p.ProcessPak(ctx, nil, func(_ string, entry *parser.PakEntrySet, _ *parser.PakFile) {
 				for _, export := range entry.Exports {
 					open.WriteString(fmt.Sprintf("Class: %s%s\n", trim(export.Export.ObjectName), BuildClassTree(export.Export.ClassIndex)))
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/fatih/color"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var coverageAssets *[]string
+var coverageFormat *string
+var coverageOutput *string
+
+func init() {
+	coverageAssets = coverageCmd.Flags().StringSliceP("assets", "a", []string{}, "Comma-separated list of asset paths to process. (supports glob)")
+	coverageFormat = coverageCmd.Flags().StringP("format", "f", "table", "Output format type (table, json)")
+	coverageOutput = coverageCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+
+	rootCmd.AddCommand(coverageCmd)
+}
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Report how much of the provided paks can be decoded",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		color.NoColor = false
+
+		coverage := parser.NewCoverage()
+
+		ctx := log.Logger.WithContext(cmd.Context())
+
+		err := processPaks(ctx, matchAssets(*coverageAssets), func(_ string, entry *parser.PakEntrySet, _ *parser.PakFile) {
+			coverage.AddEntry(entry)
+		})
+
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if *coverageOutput != "" {
+			f, err := os.OpenFile(*coverageOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+
+		switch *coverageFormat {
+		case "json":
+			resultBytes, err := json.MarshalIndent(coverage, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			_, err = out.Write(resultBytes)
+			return err
+		case "table":
+			return writeCoverageTable(out, coverage)
+		}
+
+		return fmt.Errorf("unknown output format: %s", *coverageFormat)
+	},
+}
+
+func writeCoverageTable(out io.Writer, coverage *parser.Coverage) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	writeSection := func(kind string, rows []parser.CoverageRow) {
+		fmt.Fprintf(writer, "%s\tDecoded\tPartial\tSkipped\tDecoded Bytes\tPartial Bytes\tSkipped Bytes\t\n", kind)
+
+		for _, row := range rows {
+			fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+				row.Name,
+				row.Stats.Decoded,
+				row.Stats.Partial,
+				row.Stats.Skipped,
+				row.Stats.DecodedBytes,
+				row.Stats.PartialBytes,
+				row.Stats.SkippedBytes)
+		}
+
+		fmt.Fprintln(writer, "\t\t\t\t\t\t\t")
+	}
+
+	writeSection("Class", coverage.ClassRows())
+	writeSection("Struct", coverage.StructRows())
+
+	return writer.Flush()
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/fatih/color"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var curvesAssets *[]string
+var curvesOutput *string
+var curvesStep *float32
+
+func init() {
+	curvesAssets = curvesCmd.Flags().StringSliceP("assets", "a", []string{}, "Comma-separated list of asset paths to export. (supports glob)")
+	curvesOutput = curvesCmd.Flags().StringP("output", "o", "curves", "Output directory")
+	curvesStep = curvesCmd.Flags().Float32("step", 0.1, "Time between samples")
+
+	rootCmd.AddCommand(curvesCmd)
+}
+
+var curvesCmd = &cobra.Command{
+	Use:   "curves",
+	Short: "Sample curve assets and curve tables to CSV files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		color.NoColor = false
+
+		if *curvesStep <= 0 {
+			return fmt.Errorf("step must be positive: %f", *curvesStep)
+		}
+
+		ctx := log.Logger.WithContext(cmd.Context())
+
+		return processPaks(ctx, matchAssets(*curvesAssets), func(name string, entry *parser.PakEntrySet, pak *parser.PakFile) {
+			curves := make([]parser.PakExportSet, 0)
+			for _, exportSet := range entry.Exports {
+				if exportSet.Data == nil {
+					continue
+				}
+
+				if _, ok := exportSet.Data.Data.(parser.CurveAsset); ok {
+					curves = append(curves, exportSet)
+				}
+			}
+
+			for _, exportSet := range curves {
+				asset := exportSet.Data.Data.(parser.CurveAsset)
+
+				destination := filepath.Join(*curvesOutput, strings.TrimSuffix(name, ".uexp"))
+				if len(curves) > 1 {
+					destination += "_" + strings.Trim(exportSet.Export.ObjectName, "\x00")
+				}
+				destination += ".csv"
+
+				if err := writeCurves(destination, asset); err != nil {
+					log.Error().Err(err).Msgf("Failed writing curves: %s", destination)
+					continue
+				}
+
+				log.Info().Msgf("Wrote curves: %s", destination)
+			}
+		})
+	},
+}
+
+func writeCurves(destination string, asset parser.CurveAsset) error {
+	out := &bytes.Buffer{}
+
+	if err := parser.WriteCurvesCSV(out, asset, *curvesStep); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(destination, out.Bytes(), 0644)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/fatih/color"
+	"github.com/gobwas/glob"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var depsAssets *[]string
+var depsTargets *[]string
+var depsReverse *bool
+var depsDepth *int
+var depsBroken *bool
+var depsFormat *string
+var depsOutput *string
+
+func init() {
+	depsAssets = depsCmd.Flags().StringSliceP("assets", "a", []string{}, "Comma-separated list of asset paths to process. (supports glob)")
+	depsTargets = depsCmd.Flags().StringSliceP("target", "t", []string{}, "Comma-separated list of package names to start the graph from, e.g. /Game/Path/Asset. (supports glob)")
+	depsReverse = depsCmd.Flags().BoolP("reverse", "r", false, "Point edges from dependencies to the packages using them")
+	depsDepth = depsCmd.Flags().IntP("depth", "d", 0, "Maximum amount of edges followed from the targets (0 for no limit)")
+	depsBroken = depsCmd.Flags().Bool("broken", false, "Only output references to packages absent from every pak")
+	depsFormat = depsCmd.Flags().StringP("format", "f", "dot", "Output format type (dot, graphml, json)")
+	depsOutput = depsCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+
+	rootCmd.AddCommand(depsCmd)
+}
+
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Output the dependency graph between the packages of the provided paks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		color.NoColor = false
+
+		switch *depsFormat {
+		case "dot", "graphml", "json":
+		default:
+			return fmt.Errorf("unknown output format: %s", *depsFormat)
+		}
+
+		targets := make([]glob.Glob, len(*depsTargets))
+		for i, target := range *depsTargets {
+			targets[i] = glob.MustCompile(target)
+		}
+
+		graph := parser.NewDependencyGraph()
+
+		matches := matchAssets(*depsAssets)
+		shouldProcess := func(name string) bool {
+			// Every package is registered, so references to unprocessed packages are not reported as broken
+			graph.AddFile(name)
+			return matches(name)
+		}
+
+		ctx := log.Logger.WithContext(cmd.Context())
+
+		err := processPaks(ctx, shouldProcess, func(_ string, entry *parser.PakEntrySet, _ *parser.PakFile) {
+			graph.AddEntry(entry)
+		})
+
+		if err != nil {
+			return err
+		}
+
+		options := parser.DependencyReportOptions{
+			Reverse:    *depsReverse,
+			Depth:      *depsDepth,
+			BrokenOnly: *depsBroken,
+		}
+
+		if len(targets) > 0 {
+			options.Roots = func(name string) bool {
+				for _, target := range targets {
+					if target.Match(name) {
+						return true
+					}
+				}
+
+				return false
+			}
+		}
+
+		report := graph.Report(options)
+
+		var buffer bytes.Buffer
+
+		switch *depsFormat {
+		case "dot":
+			err = report.WriteDOT(&buffer)
+		case "graphml":
+			err = report.WriteGraphML(&buffer)
+		case "json":
+			var resultBytes []byte
+			resultBytes, err = json.MarshalIndent(report, "", "  ")
+			buffer.Write(resultBytes)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if *depsOutput != "" {
+			f, err := os.OpenFile(*depsOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+
+		_, err = buffer.WriteTo(out)
+		return err
+	},
+}
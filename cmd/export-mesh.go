@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/fatih/color"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var exportMeshAssets *[]string
+var exportMeshFormat *string
+var exportMeshOutput *string
+var exportMeshLOD *int
+
+// exportableMesh is implemented by the mesh classes that can be exported
+type exportableMesh interface {
+	WriteGLB(w io.Writer, lodIndex int) error
+	WriteOBJ(w io.Writer, lodIndex int) error
+}
+
+func init() {
+	exportMeshAssets = exportMeshCmd.Flags().StringSliceP("assets", "a", []string{}, "Comma-separated list of asset paths to export. (supports glob)")
+	exportMeshFormat = exportMeshCmd.Flags().StringP("format", "f", "glb", "Output format type (glb, obj)")
+	exportMeshOutput = exportMeshCmd.Flags().StringP("output", "o", "meshes", "Output directory")
+	exportMeshLOD = exportMeshCmd.Flags().Int("lod", 0, "LOD to export")
+
+	rootCmd.AddCommand(exportMeshCmd)
+}
+
+var exportMeshCmd = &cobra.Command{
+	Use:   "export-mesh",
+	Short: "Export StaticMesh and SkeletalMesh assets as glTF or OBJ models",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		color.NoColor = false
+
+		switch *exportMeshFormat {
+		case "glb", "obj":
+		default:
+			return fmt.Errorf("unknown output format: %s", *exportMeshFormat)
+		}
+
+		ctx := log.Logger.WithContext(cmd.Context())
+
+		return processPaks(ctx, matchAssets(*exportMeshAssets), func(name string, entry *parser.PakEntrySet, pak *parser.PakFile) {
+			meshes := make([]parser.PakExportSet, 0)
+			for _, exportSet := range entry.Exports {
+				if exportSet.Data == nil {
+					continue
+				}
+
+				if _, ok := exportSet.Data.Data.(exportableMesh); ok {
+					meshes = append(meshes, exportSet)
+				}
+			}
+
+			for _, exportSet := range meshes {
+				mesh := exportSet.Data.Data.(exportableMesh)
+
+				destination := filepath.Join(*exportMeshOutput, strings.TrimSuffix(name, ".uexp"))
+				if len(meshes) > 1 {
+					destination += "_" + strings.Trim(exportSet.Export.ObjectName, "\x00")
+				}
+				destination += "." + *exportMeshFormat
+
+				if err := writeMesh(destination, mesh); err != nil {
+					log.Error().Err(err).Msgf("Failed writing mesh: %s", destination)
+					continue
+				}
+
+				log.Info().Msgf("Wrote mesh: %s", destination)
+			}
+		})
+	},
+}
+
+func writeMesh(destination string, mesh exportableMesh) error {
+	// Encode first so failed exports do not leave empty files behind
+	out := &bytes.Buffer{}
+
+	var err error
+	if *exportMeshFormat == "obj" {
+		err = mesh.WriteOBJ(out, *exportMeshLOD)
+	} else {
+		err = mesh.WriteGLB(out, *exportMeshLOD)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(destination, out.Bytes(), 0644)
+}
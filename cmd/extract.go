@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
-	"github.com/spf13/viper"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/Vilsol/ue4pak/parser"
 	"github.com/fatih/color"
@@ -16,6 +17,10 @@ import (
 )
 
 var assets *[]string
+var withIndex *bool
+var withNames *bool
+var culture *string
+var thumbnails *string
 
 func init() {
 	assets = extractCmd.Flags().StringSliceP("assets", "a", []string{}, "Comma-separated list of asset paths to extract. (supports glob) (required)")
@@ -24,11 +29,10 @@ func init() {
 	split = extractCmd.Flags().Bool("split", false, "Whether output should be split into a file per asset")
 	pretty = extractCmd.Flags().Bool("pretty", false, "Whether to output in a pretty format")
 
-	extractCmd.Flags().Bool("with-index", false, "Whether to output FPackageIndex")
-	extractCmd.Flags().Bool("with-names", false, "Whether to output names")
-
-	_ = viper.BindPFlag("with-index", extractCmd.Flags().Lookup("with-index"))
-	_ = viper.BindPFlag("with-names", extractCmd.Flags().Lookup("with-names"))
+	withIndex = extractCmd.Flags().Bool("with-index", false, "Whether to output FPackageIndex")
+	withNames = extractCmd.Flags().Bool("with-names", false, "Whether to output names")
+	culture = extractCmd.Flags().String("culture", "", "Culture to resolve text translations and string tables from, read from every pak (e.g. en, de)")
+	thumbnails = extractCmd.Flags().String("thumbnails", "", "Directory to write the editor thumbnail of each asset to")
 
 	extractCmd.MarkFlagRequired("assets")
 
@@ -54,6 +58,25 @@ var extractCmd = &cobra.Command{
 
 		results := make([]*parser.PakEntrySet, 0)
 
+		serializeOptions := &parser.SerializeOptions{
+			WithIndex: *withIndex,
+			WithNames: *withNames,
+		}
+
+		var localization *parser.FTextLocalizationResource
+		var stringTables map[string]*parser.UStringTable
+
+		if *culture != "" {
+			localization, err = loadLocalization(log.Logger.WithContext(cmd.Context()), paks, *culture)
+
+			if err != nil {
+				panic(err)
+			}
+
+			// Shared by the parsers of every pak, as texts may reference the string tables of other paks
+			stringTables = make(map[string]*parser.UStringTable)
+		}
+
 		for _, f := range paks {
 			log.Info().Msgf("Parsing file: %s", f)
 
@@ -75,8 +98,17 @@ var extractCmd = &cobra.Command{
 
 			ctx := log.Logger.WithContext(cmd.Context())
 
-			p := parser.NewParser(file)
+			p := newParser(file)
+			p.SetLocalization(localization)
+			p.SetStringTables(stringTables)
 			p.ProcessPak(ctx, shouldProcess, func(name string, entry *parser.PakEntrySet, _ *parser.PakFile) {
+				if *thumbnails != "" {
+					destination := filepath.Join(*thumbnails, strings.TrimSuffix(name, ".uexp"))
+					if err := writeThumbnails(destination, entry.Summary.Thumbnails); err != nil {
+						log.Error().Err(err).Msgf("Failed writing thumbnails: %s", destination)
+					}
+				}
+
 				if *split {
 					destination := filepath.Join(*output, name+"."+*format)
 					err := os.MkdirAll(filepath.Dir(destination), 0755)
@@ -85,7 +117,7 @@ var extractCmd = &cobra.Command{
 					}
 
 					log.Info().Msgf("Writing Result: %s", destination)
-					resultBytes := formatResults(entry)
+					resultBytes := formatResults(parser.WithSerializeOptions(entry, serializeOptions))
 					err = ioutil.WriteFile(destination, resultBytes, 0644)
 					if err != nil {
 						panic(err)
@@ -105,7 +137,7 @@ var extractCmd = &cobra.Command{
 		*/
 
 		if !*split {
-			resultBytes := formatResults(results)
+			resultBytes := formatResults(parser.WithSerializeOptions(results, serializeOptions))
 			err = ioutil.WriteFile(*output, resultBytes, 0644)
 		}
 
@@ -115,6 +147,36 @@ var extractCmd = &cobra.Command{
 	},
 }
 
+// loadLocalization reads the culture of every pak, as texts may be localized by other paks
+func loadLocalization(ctx context.Context, paks []string, culture string) (*parser.FTextLocalizationResource, error) {
+	localization := &parser.FTextLocalizationResource{
+		Entries: make(map[string]map[string]string),
+	}
+
+	for _, f := range paks {
+		log.Info().Msgf("Reading localization: %s", f)
+
+		file, err := os.OpenFile(f, os.O_RDONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		p := newParser(file)
+
+		if pak := p.Parse(ctx); pak != nil {
+			localization.Merge(pak.ReadLocalization(ctx, p, culture))
+		}
+
+		file.Close()
+	}
+
+	if len(localization.Entries) == 0 {
+		log.Warn().Msgf("No localization found for culture: %s", culture)
+	}
+
+	return localization, nil
+}
+
 func formatResults(result interface{}) []byte {
 	var resultBytes []byte
 	var err error
@@ -135,3 +197,30 @@ func formatResults(result interface{}) []byte {
 
 	return resultBytes
 }
+
+// writeThumbnails writes the thumbnail images to the destination, suffixed by the object name if there are several
+func writeThumbnails(destination string, objectThumbnails []*parser.FObjectThumbnail) error {
+	for _, thumbnail := range objectThumbnails {
+		if len(thumbnail.CompressedImageData) == 0 {
+			continue
+		}
+
+		thumbnailDestination := destination
+		if len(objectThumbnails) > 1 {
+			thumbnailDestination += "_" + thumbnail.ObjectPath
+		}
+		thumbnailDestination += "." + thumbnail.Format
+
+		if err := os.MkdirAll(filepath.Dir(thumbnailDestination), 0755); err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(thumbnailDestination, thumbnail.CompressedImageData, 0644); err != nil {
+			return err
+		}
+
+		log.Info().Msgf("Wrote thumbnail: %s", thumbnailDestination)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Vilsol/ue4pak/parser/assetregistry"
+	"github.com/fatih/color"
+	"github.com/gobwas/glob"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var registryClasses *[]string
+var registryTags *[]string
+var registryFormat *string
+var registryOutput *string
+
+func init() {
+	registryClasses = registryCmd.Flags().StringSliceP("class", "c", []string{}, "Comma-separated list of asset classes to match. (supports glob)")
+	registryTags = registryCmd.Flags().StringSliceP("tag", "t", []string{}, "Comma-separated list of Key=Value tags that must all match. (value supports glob)")
+	registryFormat = registryCmd.Flags().StringP("format", "f", "table", "Output format type (table, json)")
+	registryOutput = registryCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+
+	rootCmd.AddCommand(registryCmd)
+}
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Search the assets of AssetRegistry.bin by class or tag value",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		color.NoColor = false
+
+		switch *registryFormat {
+		case "table", "json":
+		default:
+			return fmt.Errorf("unknown output format: %s", *registryFormat)
+		}
+
+		classes := make([]glob.Glob, len(*registryClasses))
+		for i, class := range *registryClasses {
+			classes[i] = glob.MustCompile(class)
+		}
+
+		tags := make(map[string]glob.Glob, len(*registryTags))
+		for _, tag := range *registryTags {
+			parts := strings.SplitN(tag, "=", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("invalid tag filter, expected Key=Value: %s", tag)
+			}
+
+			tags[parts[0]] = glob.MustCompile(parts[1])
+		}
+
+		matches := func(asset *assetregistry.AssetData) bool {
+			if len(classes) > 0 {
+				found := false
+				for _, class := range classes {
+					if class.Match(asset.AssetClass) {
+						found = true
+						break
+					}
+				}
+
+				if !found {
+					return false
+				}
+			}
+
+			for key, value := range tags {
+				if tagValue, ok := asset.Tags[key]; !ok || !value.Match(tagValue) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		paks, err := filepath.Glob(cmd.Flag("pak").Value.String())
+		if err != nil {
+			return err
+		}
+
+		results := make([]*assetregistry.AssetData, 0)
+
+		for _, f := range paks {
+			log.Info().Msgf("Parsing file: %s", f)
+
+			file, err := os.OpenFile(f, os.O_RDONLY, 0644)
+			if err != nil {
+				return err
+			}
+
+			ctx := log.Logger.WithContext(cmd.Context())
+
+			p := newParser(file)
+			pak := p.Parse(ctx)
+
+			if pak == nil {
+				file.Close()
+				continue
+			}
+
+			for _, record := range pak.Index.Records {
+				name := strings.Trim(record.FileName, "\x00")
+				if !strings.HasSuffix(name, "AssetRegistry.bin") {
+					continue
+				}
+
+				if record.IsEncrypted {
+					log.Warn().Msgf("Skipping encrypted asset registry: %s", name)
+					continue
+				}
+
+				log.Info().Msgf("Reading Asset Registry: %s", name)
+
+				registry, err := assetregistry.Parse(record.ReadData(pak, p))
+				if err != nil {
+					log.Error().Err(err).Msgf("Failed reading asset registry: %s", name)
+					continue
+				}
+
+				for _, asset := range registry.Assets {
+					if matches(asset) {
+						results = append(results, asset)
+					}
+				}
+			}
+
+			file.Close()
+		}
+
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].ObjectPath < results[j].ObjectPath
+		})
+
+		var out io.Writer = os.Stdout
+		if *registryOutput != "" {
+			f, err := os.OpenFile(*registryOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+
+		if *registryFormat == "json" {
+			resultBytes, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			_, err = out.Write(resultBytes)
+			return err
+		}
+
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "Asset\tClass\t")
+
+		for _, asset := range results {
+			fmt.Fprintf(writer, "%s\t%s\t\n", asset.ObjectPath, asset.AssetClass)
+		}
+
+		return writer.Flush()
+	},
+}
@@ -1,25 +1,36 @@
 package cmd
 
 import (
+	"encoding/hex"
+	"fmt"
 	"github.com/rs/zerolog"
 	"os"
+	"strings"
 	"time"
 
-	_ "github.com/Vilsol/ue4pak/parser/games/satisfactory"
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/Vilsol/ue4pak/parser/games"
+	"github.com/Vilsol/ue4pak/parser/games/satisfactory"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var PakFile string
 var LogLevel string
 var ForceColors bool
 var NoPreload bool
+var Schemas []string
+var Game string
+var AESKeys []string
+
+var registry *parser.Registry
+var gameProfile games.Profile
+var aesKeys [][]byte
 
 var rootCmd = &cobra.Command{
 	Use:   "ue4pak",
 	Short: "ue4pak parses and extracts data from UE4 Pak files",
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		level, err := zerolog.ParseLevel(LogLevel)
 		if err != nil {
 			log.Err(err).Msg("Invalid log level")
@@ -32,7 +43,37 @@ var rootCmd = &cobra.Command{
 			TimeFormat: time.RFC3339,
 		}).With().Timestamp().Logger()
 
-		viper.Set("NoPreload", NoPreload)
+		if Game != "" {
+			profile, ok := games.Get(Game)
+			if !ok {
+				return fmt.Errorf("unknown game: %s (available: %s)", Game, strings.Join(games.Names(), ", "))
+			}
+
+			gameProfile = profile
+			registry = games.NewRegistry(profile)
+		} else {
+			registry = parser.DefaultRegistry()
+		}
+
+		for _, key := range AESKeys {
+			decoded, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid AES key %s: %w", key, err)
+			}
+
+			aesKeys = append(aesKeys, decoded)
+		}
+
+		for _, path := range Schemas {
+			schema, err := parser.LoadSchema(path)
+			if err != nil {
+				return fmt.Errorf("failed loading schema %s: %w", path, err)
+			}
+
+			schema.Register(registry)
+		}
+
+		return nil
 	},
 }
 
@@ -44,9 +85,32 @@ func Execute() {
 }
 
 func init() {
+	games.Register(satisfactory.Profile{})
+
 	rootCmd.PersistentFlags().StringVarP(&PakFile, "pak", "p", "", "The path to pak file (supports glob) (required)")
 	rootCmd.PersistentFlags().StringVar(&LogLevel, "log", "info", "The log level to output")
 	rootCmd.PersistentFlags().BoolVar(&ForceColors, "colors", false, "Force output with colors")
 	rootCmd.PersistentFlags().BoolVar(&NoPreload, "no-preload", false, "Do not preload data (slower, but guaranteed to read)")
+	rootCmd.PersistentFlags().StringSliceVar(&Schemas, "schema", []string{}, "Comma-separated list of struct and class schema files (json or yaml)")
+	rootCmd.PersistentFlags().StringVarP(&Game, "game", "g", "", "The game profile to parse with ("+strings.Join(games.Names(), ", ")+")")
+	rootCmd.PersistentFlags().StringSliceVar(&AESKeys, "aes-key", []string{}, "Comma-separated list of hex AES keys for encrypted pak indexes")
 	rootCmd.MarkPersistentFlagRequired("pak")
 }
+
+// newParser creates a parser sharing the registry of the selected game profile and schemas
+func newParser(reader parser.PakReader) *parser.PakParser {
+	p := parser.NewParser(reader, &parser.ParserOptions{
+		Registry:  registry,
+		NoPreload: NoPreload,
+	})
+
+	if gameProfile != nil {
+		games.Configure(p, gameProfile)
+	}
+
+	for _, key := range aesKeys {
+		p.AddAESKey(key)
+	}
+
+	return p
+}
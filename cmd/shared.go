@@ -1,6 +1,62 @@
 package cmd
 
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/gobwas/glob"
+	"github.com/rs/zerolog/log"
+)
+
 var format *string
 var output *string
 var split *bool
 var pretty *bool
+
+// matchAssets returns whether a file matches any of the asset globs, every file matching when there are none
+func matchAssets(assets []string) func(string) bool {
+	patterns := make([]glob.Glob, len(assets))
+	for i, asset := range assets {
+		patterns[i] = glob.MustCompile(asset)
+	}
+
+	return func(name string) bool {
+		if len(patterns) == 0 {
+			return true
+		}
+
+		for _, pattern := range patterns {
+			if pattern.Match(name) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// processPaks processes every pak matching the pak flag in turn
+func processPaks(ctx context.Context, shouldProcess func(string) bool, done func(string, *parser.PakEntrySet, *parser.PakFile)) error {
+	paks, err := filepath.Glob(PakFile)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range paks {
+		log.Info().Msgf("Parsing file: %s", f)
+
+		file, err := os.OpenFile(f, os.O_RDONLY, 0644)
+		if err != nil {
+			return err
+		}
+
+		p := newParser(file)
+		p.ProcessPak(ctx, shouldProcess, done)
+
+		file.Close()
+	}
+
+	return nil
+}
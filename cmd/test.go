@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/Vilsol/ue4pak/parser"
 	"github.com/fatih/color"
 
 	"github.com/spf13/cobra"
@@ -62,7 +61,7 @@ var testCmd = &cobra.Command{
 
 			ctx := log.Logger.WithContext(cmd.Context())
 
-			p := parser.NewParser(file)
+			p := newParser(file)
 			p.ProcessPak(ctx, shouldProcess, nil)
 			/*
 				f, err := os.OpenFile("dump.txt", os.O_WRONLY | os.O_CREATE, 0644)
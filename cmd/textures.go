@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/ue4pak/parser"
+	"github.com/fatih/color"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var texturesAssets *[]string
+var texturesFormat *string
+var texturesOutput *string
+var texturesMip *int
+
+func init() {
+	texturesAssets = texturesCmd.Flags().StringSliceP("assets", "a", []string{}, "Comma-separated list of asset paths to export. (supports glob)")
+	texturesFormat = texturesCmd.Flags().StringP("format", "f", "png", "Output format type (png, tga, dds)")
+	texturesOutput = texturesCmd.Flags().StringP("output", "o", "textures", "Output directory")
+	texturesMip = texturesCmd.Flags().Int("mip", -1, "Mip to export (defaults to the largest available)")
+
+	rootCmd.AddCommand(texturesCmd)
+}
+
+var texturesCmd = &cobra.Command{
+	Use:   "textures",
+	Short: "Export Texture2D assets as images",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		color.NoColor = false
+
+		switch *texturesFormat {
+		case "png", "tga", "dds":
+		default:
+			return fmt.Errorf("unknown output format: %s", *texturesFormat)
+		}
+
+		ctx := log.Logger.WithContext(cmd.Context())
+
+		return processPaks(ctx, matchAssets(*texturesAssets), func(name string, entry *parser.PakEntrySet, pak *parser.PakFile) {
+			textures := make([]parser.PakExportSet, 0)
+			for _, exportSet := range entry.Exports {
+				if exportSet.Data == nil {
+					continue
+				}
+
+				if _, ok := exportSet.Data.Data.(*parser.Texture2D); ok {
+					textures = append(textures, exportSet)
+				}
+			}
+
+			for _, exportSet := range textures {
+				texture := exportSet.Data.Data.(*parser.Texture2D)
+
+				destination := filepath.Join(*texturesOutput, strings.TrimSuffix(name, ".uexp"))
+				if len(textures) > 1 {
+					destination += "_" + strings.Trim(exportSet.Export.ObjectName, "\x00")
+				}
+
+				if err := writeTexture(ctx, destination, texture); err != nil {
+					log.Error().Err(err).Msgf("Failed writing texture: %s", destination)
+				}
+			}
+		})
+	},
+}
+
+// writeTexture writes the texture to the destination, suffixed by the slice index for texture arrays and cubemaps
+func writeTexture(ctx context.Context, destination string, texture *parser.Texture2D) error {
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+
+	if *texturesFormat == "dds" {
+		return writeTextureFile(destination+".dds", func(out io.Writer) error {
+			return texture.WriteDDS(out, *texturesMip)
+		})
+	}
+
+	images := texture.ToImages(ctx, *texturesMip)
+	if images == nil {
+		return fmt.Errorf("unable to decode texture")
+	}
+
+	for i, img := range images {
+		sliceDestination := destination
+		if len(images) > 1 {
+			sliceDestination += "_" + strconv.Itoa(i)
+		}
+		sliceDestination += "." + *texturesFormat
+
+		err := writeTextureFile(sliceDestination, func(out io.Writer) error {
+			if *texturesFormat == "tga" {
+				return parser.WriteTGA(out, img)
+			}
+
+			return png.Encode(out, img)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeTextureFile(destination string, encode func(io.Writer) error) error {
+	f, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := encode(f); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Wrote texture: %s", destination)
+
+	return nil
+}
@@ -0,0 +1,403 @@
+package assetregistry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vilsol/ue4pak/parser"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/AssetRegistry/Public/AssetRegistryState.h
+const (
+	VersionPreVersioning = iota
+	VersionHardSoftDependencies
+	VersionAddAssetRegistryState
+	VersionChangedAssetData
+	VersionRemovedMD5Hash
+	VersionAddedHardManage
+	VersionAddedCookedMD5Hash
+	VersionAddedDependencyFlags
+	VersionFixedTags
+)
+
+var versionGuid = parser.FGuid{A: 0x717F9EE7, B: 0xE9B0493A, C: 0x88B39132, D: 0x1B388107}
+
+const (
+	DependencyCategoryPackage = "Package"
+	DependencyCategoryName    = "Name"
+	DependencyCategoryManage  = "Manage"
+
+	// packageFlagSetWidth and manageFlagSetWidth are the flag bits stored per dependency since AddedDependencyFlags
+	packageFlagSetWidth = 3
+	manageFlagSetWidth  = 1
+)
+
+type AssetRegistry struct {
+	Version      int32                        `json:"version"`
+	Assets       []*AssetData                 `json:"assets"`
+	DependsNodes []*DependsNode               `json:"depends_nodes"`
+	PackageData  map[string]*AssetPackageData `json:"package_data"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/CoreUObject/Public/AssetData.h
+type AssetData struct {
+	ObjectPath   string            `json:"object_path"`
+	PackagePath  string            `json:"package_path"`
+	AssetClass   string            `json:"asset_class"`
+	PackageName  string            `json:"package_name"`
+	AssetName    string            `json:"asset_name"`
+	Tags         map[string]string `json:"tags"`
+	ChunkIDs     []int32           `json:"chunk_ids"`
+	PackageFlags uint32            `json:"package_flags"`
+}
+
+type AssetIdentifier struct {
+	PackageName      string `json:"package_name,omitempty"`
+	PrimaryAssetType string `json:"primary_asset_type,omitempty"`
+	ObjectName       string `json:"object_name,omitempty"`
+	ValueName        string `json:"value_name,omitempty"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/AssetRegistry/Public/DependsNode.h
+type DependsNode struct {
+	Identifier   *AssetIdentifier `json:"identifier"`
+	Dependencies []*Dependency    `json:"dependencies"`
+	Referencers  []int32          `json:"referencers"`
+}
+
+// Dependency references another node of the graph by its index
+type Dependency struct {
+	Node     int32  `json:"node"`
+	Category string `json:"category"`
+	Hard     bool   `json:"hard"`
+}
+
+type AssetPackageData struct {
+	DiskSize    int64         `json:"disk_size"`
+	PackageGuid *parser.FGuid `json:"package_guid"`
+	CookedHash  []byte        `json:"cooked_hash,omitempty"`
+}
+
+// reader reads names from the name table of the registry, and tag maps from the fixed tag store since FixedTags
+type reader struct {
+	*parser.PakParser
+
+	version int32
+	names   []string
+	store   *fixedTagStore
+}
+
+// Parse decodes the contents of an AssetRegistry.bin file
+func Parse(data []byte) (registry *AssetRegistry, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			registry = nil
+			err = fmt.Errorf("failed parsing asset registry: %v", r)
+		}
+	}()
+
+	r := &reader{
+		PakParser: parser.NewParser(&parser.PakByteReader{Bytes: data}, &parser.ParserOptions{NoPreload: true}),
+	}
+
+	registry = &AssetRegistry{
+		Version:     VersionPreVersioning,
+		PackageData: make(map[string]*AssetPackageData),
+	}
+
+	if guid := r.ReadFGuid(); *guid == versionGuid {
+		registry.Version = r.ReadInt32()
+	}
+
+	if registry.Version < VersionRemovedMD5Hash {
+		return nil, fmt.Errorf("unsupported asset registry version: %d", registry.Version)
+	}
+
+	r.version = registry.Version
+
+	if r.version < VersionFixedTags {
+		r.readNameTable()
+	} else {
+		r.readNameBatch()
+		r.store = r.readFixedTagStore()
+	}
+
+	assetCount := r.ReadInt32()
+	registry.Assets = make([]*AssetData, assetCount)
+	for i := range registry.Assets {
+		registry.Assets[i] = r.readAssetData()
+	}
+
+	nodeCount := r.ReadInt32()
+	registry.DependsNodes = make([]*DependsNode, nodeCount)
+	for i := range registry.DependsNodes {
+		if r.version < VersionAddedDependencyFlags {
+			registry.DependsNodes[i] = r.readDependsNodeBeforeFlags()
+		} else {
+			registry.DependsNodes[i] = r.readDependsNode()
+		}
+	}
+
+	packageCount := r.ReadInt32()
+	for i := int32(0); i < packageCount; i++ {
+		name := r.readName()
+		registry.PackageData[name] = r.readAssetPackageData()
+	}
+
+	return registry, nil
+}
+
+// readNameTable reads the name table at the offset stored at the current position
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/CoreUObject/Public/UObject/NameTableArchive.h
+func (r *reader) readNameTable() {
+	offset := r.ReadInt64()
+
+	if offset <= 0 {
+		return
+	}
+
+	current := r.Tell()
+	r.Seek(offset, 0)
+
+	count := r.ReadInt32()
+	r.names = make([]string, count)
+
+	for i := range r.names {
+		r.names[i] = strings.Trim(r.ReadString(), "\x00")
+
+		// Non case preserving and case preserving hashes
+		r.ReadUint32()
+	}
+
+	r.Seek(current, 0)
+}
+
+// readNameBatch reads the names saved as a batch of hashes, headers and strings
+func (r *reader) readNameBatch() {
+	count := r.ReadInt32()
+	if count <= 0 {
+		return
+	}
+
+	r.ReadUint32() // NumStringBytes
+	r.ReadUint64() // HashVersion
+	r.Read(count * 8)
+
+	headers := r.Read(count * 2)
+	r.names = make([]string, count)
+
+	for i := range r.names {
+		utf16 := headers[i*2]&0x80 != 0
+		length := int32(headers[i*2]&0x7F)<<8 | int32(headers[i*2+1])
+
+		if utf16 {
+			data := r.Read(length * 2)
+			runes := make([]rune, length)
+			for j := range runes {
+				runes[j] = rune(uint16(data[j*2]) | uint16(data[j*2+1])<<8)
+			}
+			r.names[i] = string(runes)
+		} else {
+			r.names[i] = string(r.Read(length))
+		}
+	}
+}
+
+func (r *reader) readName() string {
+	index := r.ReadInt32()
+	number := r.ReadInt32()
+
+	return r.nameAt(index, number)
+}
+
+// nameAt returns the name with the instance number appended as UE does, numbers being stored plus one
+func (r *reader) nameAt(index int32, number int32) string {
+	if index < 0 || int(index) >= len(r.names) {
+		panic(fmt.Sprintf("name index out of range: %d", index))
+	}
+
+	if number > 0 {
+		return fmt.Sprintf("%s_%d", r.names[index], number-1)
+	}
+
+	return r.names[index]
+}
+
+func (r *reader) readAssetData() *AssetData {
+	asset := &AssetData{
+		ObjectPath:  r.readName(),
+		PackagePath: r.readName(),
+		AssetClass:  r.readName(),
+		PackageName: r.readName(),
+		AssetName:   r.readName(),
+	}
+
+	if r.store != nil {
+		asset.Tags = r.store.tagMap(r.ReadUint64())
+	} else {
+		count := r.ReadInt32()
+		asset.Tags = make(map[string]string, count)
+
+		for i := int32(0); i < count; i++ {
+			key := r.readName()
+			asset.Tags[key] = strings.TrimSuffix(r.ReadString(), "\x00")
+		}
+	}
+
+	chunkCount := r.ReadInt32()
+	asset.ChunkIDs = make([]int32, chunkCount)
+	for i := range asset.ChunkIDs {
+		asset.ChunkIDs[i] = r.ReadInt32()
+	}
+
+	asset.PackageFlags = r.ReadUint32()
+
+	return asset
+}
+
+func (r *reader) readAssetIdentifier() *AssetIdentifier {
+	fields := r.Read(1)[0]
+	identifier := &AssetIdentifier{}
+
+	if fields&(1<<0) != 0 {
+		identifier.PackageName = r.readName()
+	}
+
+	if fields&(1<<1) != 0 {
+		identifier.PrimaryAssetType = r.readName()
+	}
+
+	if fields&(1<<2) != 0 {
+		identifier.ObjectName = r.readName()
+	}
+
+	if fields&(1<<3) != 0 {
+		identifier.ValueName = r.readName()
+	}
+
+	return identifier
+}
+
+// readDependsNodeBeforeFlags reads a node storing the count of every dependency list before the lists
+func (r *reader) readDependsNodeBeforeFlags() *DependsNode {
+	node := &DependsNode{
+		Identifier:   r.readAssetIdentifier(),
+		Dependencies: make([]*Dependency, 0),
+	}
+
+	hardCount := r.ReadInt32()
+	softCount := r.ReadInt32()
+	nameCount := r.ReadInt32()
+	softManageCount := r.ReadInt32()
+
+	hardManageCount := int32(0)
+	if r.version >= VersionAddedHardManage {
+		hardManageCount = r.ReadInt32()
+	}
+
+	referencerCount := r.ReadInt32()
+
+	lists := []struct {
+		count    int32
+		category string
+		hard     bool
+	}{
+		{hardCount, DependencyCategoryPackage, true},
+		{softCount, DependencyCategoryPackage, false},
+		{nameCount, DependencyCategoryName, false},
+		{softManageCount, DependencyCategoryManage, false},
+		{hardManageCount, DependencyCategoryManage, true},
+	}
+
+	for _, list := range lists {
+		for i := int32(0); i < list.count; i++ {
+			node.Dependencies = append(node.Dependencies, &Dependency{
+				Node:     r.ReadInt32(),
+				Category: list.category,
+				Hard:     list.hard,
+			})
+		}
+	}
+
+	node.Referencers = make([]int32, referencerCount)
+	for i := range node.Referencers {
+		node.Referencers[i] = r.ReadInt32()
+	}
+
+	return node
+}
+
+// readDependsNode reads a node storing each dependency list with its flag bits
+func (r *reader) readDependsNode() *DependsNode {
+	node := &DependsNode{
+		Identifier:   r.readAssetIdentifier(),
+		Dependencies: make([]*Dependency, 0),
+	}
+
+	for _, category := range []string{DependencyCategoryPackage, DependencyCategoryName, DependencyCategoryManage} {
+		width := 0
+		switch category {
+		case DependencyCategoryPackage:
+			width = packageFlagSetWidth
+		case DependencyCategoryManage:
+			width = manageFlagSetWidth
+		}
+
+		indices, flags := r.readDependencies(width)
+
+		for i, index := range indices {
+			dependency := &Dependency{
+				Node:     index,
+				Category: category,
+			}
+
+			// The first flag of packages is Hard, of manage dependencies is Direct
+			if width > 0 {
+				bit := i * width
+				dependency.Hard = flags[bit/32]&(1<<uint(bit%32)) != 0
+			}
+
+			node.Dependencies = append(node.Dependencies, dependency)
+		}
+	}
+
+	node.Referencers, _ = r.readDependencies(0)
+
+	return node
+}
+
+func (r *reader) readDependencies(flagWidth int) ([]int32, []uint32) {
+	count := r.ReadInt32()
+	indices := make([]int32, count)
+
+	for i := range indices {
+		indices[i] = r.ReadInt32()
+	}
+
+	if flagWidth == 0 {
+		return indices, nil
+	}
+
+	flags := make([]uint32, (int(count)*flagWidth+31)/32)
+	for i := range flags {
+		flags[i] = r.ReadUint32()
+	}
+
+	return indices, flags
+}
+
+func (r *reader) readAssetPackageData() *AssetPackageData {
+	data := &AssetPackageData{
+		DiskSize:    r.ReadInt64(),
+		PackageGuid: r.ReadFGuid(),
+	}
+
+	if r.version >= VersionAddedCookedMD5Hash {
+		// FMD5Hash stores whether it is valid before the hash
+		if r.ReadInt32() != 0 {
+			data.CookedHash = r.Read(16)
+		}
+	}
+
+	return data
+}
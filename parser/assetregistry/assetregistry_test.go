@@ -0,0 +1,264 @@
+package assetregistry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+// registryWriter builds registry data in the little endian layout the reader expects
+type registryWriter struct {
+	bytes.Buffer
+}
+
+func (w *registryWriter) write(values ...interface{}) {
+	for _, value := range values {
+		if err := binary.Write(&w.Buffer, binary.LittleEndian, value); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (w *registryWriter) writeString(value string) {
+	w.write(int32(len(value) + 1))
+	w.WriteString(value)
+	w.WriteByte(0)
+}
+
+func (w *registryWriter) writeName(index int32, number int32) {
+	w.write(index, number)
+}
+
+func (w *registryWriter) writeHeader(version int32) {
+	w.write(versionGuid.A, versionGuid.B, versionGuid.C, versionGuid.D, version)
+}
+
+func TestParseNameTable(t *testing.T) {
+	names := []string{"/Game/A.A", "/Game", "Texture2D", "/Game/A", "A", "Key"}
+
+	w := &registryWriter{}
+	w.writeHeader(VersionAddedCookedMD5Hash)
+
+	offsetPosition := w.Len()
+	w.write(int64(0))
+
+	// Assets
+	w.write(int32(1))
+	w.writeName(0, 0)
+	w.writeName(1, 0)
+	w.writeName(2, 0)
+	w.writeName(3, 0)
+	w.writeName(4, 0)
+	w.write(int32(1))
+	w.writeName(5, 0)
+	w.writeString("Value")
+	w.write(int32(1), int32(7))
+	w.write(uint32(0x10))
+
+	// Depends nodes, counting hard, soft, name, soft manage, hard manage and referencers
+	w.write(int32(1))
+	w.write(uint8(1))
+	w.writeName(3, 0)
+	w.write(int32(1), int32(0), int32(0), int32(0), int32(1), int32(1))
+	w.write(int32(0))
+	w.write(int32(0))
+	w.write(int32(0))
+
+	// Package data
+	w.write(int32(1))
+	w.writeName(3, 2)
+	w.write(int64(100), uint32(1), uint32(2), uint32(3), uint32(4))
+	w.write(int32(1))
+	w.Write(bytes.Repeat([]byte{0xAB}, 16))
+
+	nameTable := int64(w.Len())
+	w.write(int32(len(names)))
+	for _, name := range names {
+		w.writeString(name)
+		w.write(uint32(0))
+	}
+
+	data := w.Bytes()
+	binary.LittleEndian.PutUint64(data[offsetPosition:], uint64(nameTable))
+
+	registry, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAsset := &AssetData{
+		ObjectPath:   "/Game/A.A",
+		PackagePath:  "/Game",
+		AssetClass:   "Texture2D",
+		PackageName:  "/Game/A",
+		AssetName:    "A",
+		Tags:         map[string]string{"Key": "Value"},
+		ChunkIDs:     []int32{7},
+		PackageFlags: 0x10,
+	}
+
+	if len(registry.Assets) != 1 || !reflect.DeepEqual(registry.Assets[0], wantAsset) {
+		t.Errorf("unexpected assets: %+v", registry.Assets)
+	}
+
+	wantNode := &DependsNode{
+		Identifier: &AssetIdentifier{PackageName: "/Game/A"},
+		Dependencies: []*Dependency{
+			{Node: 0, Category: DependencyCategoryPackage, Hard: true},
+			{Node: 0, Category: DependencyCategoryManage, Hard: true},
+		},
+		Referencers: []int32{0},
+	}
+
+	if len(registry.DependsNodes) != 1 || !reflect.DeepEqual(registry.DependsNodes[0], wantNode) {
+		t.Errorf("unexpected depends nodes: %+v", registry.DependsNodes)
+	}
+
+	packageData, ok := registry.PackageData["/Game/A_1"]
+	if !ok {
+		t.Fatalf("missing package data: %+v", registry.PackageData)
+	}
+
+	if packageData.DiskSize != 100 || packageData.PackageGuid.D != 4 || !bytes.Equal(packageData.CookedHash, bytes.Repeat([]byte{0xAB}, 16)) {
+		t.Errorf("unexpected package data: %+v", packageData)
+	}
+}
+
+func TestParseFixedTags(t *testing.T) {
+	for _, magic := range []uint32{oldBeginMagic, beginMagic} {
+		registry, err := Parse(fixedTagsRegistry(magic))
+		if err != nil {
+			t.Fatalf("magic %x: %v", magic, err)
+		}
+
+		wantTags := map[string]string{
+			"Ansi":  "de",
+			"Wide":  "ü",
+			"Class": "Texture2D",
+			"Path":  "Texture2D'/Game/A.A'",
+			"Text":  "Hello",
+			"Größe": "abc",
+		}
+
+		if len(registry.Assets) != 1 || !reflect.DeepEqual(registry.Assets[0].Tags, wantTags) {
+			t.Errorf("magic %x: unexpected tags: %+v", magic, registry.Assets[0].Tags)
+		}
+
+		wantNode := &DependsNode{
+			Identifier: &AssetIdentifier{PackageName: "/Game/A"},
+			Dependencies: []*Dependency{
+				{Node: 0, Category: DependencyCategoryPackage, Hard: true},
+				{Node: 0, Category: DependencyCategoryManage, Hard: false},
+			},
+			Referencers: []int32{},
+		}
+
+		if len(registry.DependsNodes) != 1 || !reflect.DeepEqual(registry.DependsNodes[0], wantNode) {
+			t.Errorf("magic %x: unexpected depends nodes: %+v", magic, registry.DependsNodes)
+		}
+
+		if packageData, ok := registry.PackageData["/Game/A"]; !ok || packageData.CookedHash != nil {
+			t.Errorf("magic %x: unexpected package data: %+v", magic, registry.PackageData)
+		}
+	}
+}
+
+// fixedTagsRegistry builds a 4.27 registry, storing its names as a batch and its tags in a fixed tag store
+func fixedTagsRegistry(magic uint32) []byte {
+	names := []string{"/Game/A.A", "/Game", "Texture2D", "/Game/A", "A", "Ansi", "Wide", "Class", "Path", "Text", "Größe"}
+
+	w := &registryWriter{}
+	w.writeHeader(VersionFixedTags)
+
+	// Name batch of hashes, headers and strings, the high bit of a header marking UTF-16 strings
+	w.write(int32(len(names)), uint32(0), uint64(0))
+	w.Write(make([]byte, len(names)*8))
+
+	var strings bytes.Buffer
+	for _, name := range names {
+		if runes := []rune(name); len(runes) != len(name) {
+			w.write(uint8(0x80|len(runes)>>8), uint8(len(runes)))
+			for _, char := range utf16.Encode(runes) {
+				binary.Write(&strings, binary.LittleEndian, char)
+			}
+		} else {
+			w.write(uint8(len(name)>>8), uint8(len(name)))
+			strings.WriteString(name)
+		}
+	}
+	w.Write(strings.Bytes())
+
+	// Fixed tag store counts of numberless names, names, numberless export paths, export paths, texts, ANSI string
+	// offsets, wide string offsets, ANSI string bytes, wide string characters, numberless pairs and pairs
+	w.write(magic)
+	w.write(int32(1), int32(0), int32(1), int32(0), int32(1), int32(2), int32(1), int32(7), int32(2), int32(6), int32(0))
+
+	if magic == beginMagic {
+		w.write(uint32(0))
+		w.writeString("Hello")
+	}
+
+	w.write(int32(2))
+	w.write(int32(2), int32(4), int32(3))
+
+	if magic == oldBeginMagic {
+		w.writeString("Hello")
+	}
+
+	w.write(int32(0), int32(4))
+	w.write(int32(0))
+	w.WriteString("abc\x00de\x00")
+	w.write(uint16('ü'), uint16(0))
+
+	// Pairs of key names and value ids, the lowest 3 bits of an id being its type
+	w.write(int32(5), uint32(1<<3|valueTypeAnsiString))
+	w.write(int32(6), uint32(0<<3|valueTypeWideString))
+	w.write(int32(7), uint32(0<<3|valueTypeNumberlessName))
+	w.write(int32(8), uint32(0<<3|valueTypeNumberlessExportPath))
+	w.write(int32(9), uint32(0<<3|valueTypeLocalizedText))
+	w.write(int32(10), uint32(0<<3|valueTypeAnsiString))
+	w.write(uint32(endMagic))
+
+	// Assets, referencing the numberless pairs by a handle
+	w.write(int32(1))
+	w.writeName(0, 0)
+	w.writeName(1, 0)
+	w.writeName(2, 0)
+	w.writeName(3, 0)
+	w.writeName(4, 0)
+	w.write(uint64(1<<63 | 6<<32 | 0))
+	w.write(int32(0))
+	w.write(uint32(0))
+
+	// Depends nodes, each list followed by its flag bits
+	w.write(int32(1))
+	w.write(uint8(1))
+	w.writeName(3, 0)
+	w.write(int32(1), int32(0), uint32(1))
+	w.write(int32(0))
+	w.write(int32(1), int32(0), uint32(0))
+	w.write(int32(0))
+
+	// Package data
+	w.write(int32(1))
+	w.writeName(3, 0)
+	w.write(int64(100), uint32(1), uint32(2), uint32(3), uint32(4))
+	w.write(int32(0))
+
+	return w.Bytes()
+}
+
+func TestParseInvalid(t *testing.T) {
+	w := &registryWriter{}
+	w.writeHeader(VersionAddedHardManage)
+
+	if _, err := Parse(w.Bytes()); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+
+	if _, err := Parse(fixedTagsRegistry(beginMagic)[:100]); err == nil {
+		t.Error("expected an error for truncated data")
+	}
+}
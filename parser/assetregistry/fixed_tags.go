@@ -0,0 +1,213 @@
+package assetregistry
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	oldBeginMagic = 0x12345678
+	beginMagic    = 0x12345679
+	endMagic      = 0x87654321
+)
+
+// Value types of a value id, stored in its lowest 3 bits
+const (
+	valueTypeAnsiString = iota
+	valueTypeWideString
+	valueTypeNumberlessName
+	valueTypeName
+	valueTypeNumberlessExportPath
+	valueTypeExportPath
+	valueTypeLocalizedText
+)
+
+// fixedTagStore holds the tag maps of all assets since FixedTags, deduplicating their keys and values
+// https://github.com/EpicGames/UnrealEngine/blob/4.27/Engine/Source/Runtime/CoreUObject/Private/AssetRegistry/AssetDataTagMap.cpp
+type fixedTagStore struct {
+	numberlessNames       []string
+	names                 []string
+	numberlessExportPaths []string
+	exportPaths           []string
+	texts                 []string
+	ansiStrings           []string
+	wideStrings           []string
+	numberlessPairs       []fixedTagPair
+	pairs                 []fixedTagPair
+}
+
+type fixedTagPair struct {
+	key   string
+	value uint32
+}
+
+func (r *reader) readFixedTagStore() *fixedTagStore {
+	magic := r.ReadUint32()
+	if magic != oldBeginMagic && magic != beginMagic {
+		panic(fmt.Sprintf("invalid tag store magic: %x", magic))
+	}
+
+	// NumberlessNames, Names, NumberlessExportPaths, ExportPaths, Texts, AnsiStringOffsets, WideStringOffsets,
+	// AnsiStrings, WideStrings, NumberlessPairs and Pairs
+	counts := make([]int32, 11)
+	for i := range counts {
+		counts[i] = r.ReadInt32()
+	}
+
+	store := &fixedTagStore{}
+
+	readTexts := func() {
+		store.texts = make([]string, counts[4])
+		for i := range store.texts {
+			store.texts[i] = strings.TrimSuffix(r.ReadString(), "\x00")
+		}
+	}
+
+	// Texts are stored first by the newer layout
+	if magic == beginMagic {
+		r.ReadUint32() // TextDataBytes
+		readTexts()
+	}
+
+	store.numberlessNames = make([]string, counts[0])
+	for i := range store.numberlessNames {
+		store.numberlessNames[i] = r.nameAt(r.ReadInt32(), 0)
+	}
+
+	store.names = make([]string, counts[1])
+	for i := range store.names {
+		store.names[i] = r.readName()
+	}
+
+	store.numberlessExportPaths = make([]string, counts[2])
+	for i := range store.numberlessExportPaths {
+		class, object, pkg := r.nameAt(r.ReadInt32(), 0), r.nameAt(r.ReadInt32(), 0), r.nameAt(r.ReadInt32(), 0)
+		store.numberlessExportPaths[i] = exportPath(class, object, pkg)
+	}
+
+	store.exportPaths = make([]string, counts[3])
+	for i := range store.exportPaths {
+		class, object, pkg := r.readName(), r.readName(), r.readName()
+		store.exportPaths[i] = exportPath(class, object, pkg)
+	}
+
+	if magic == oldBeginMagic {
+		readTexts()
+	}
+
+	ansiOffsets := make([]int32, counts[5])
+	for i := range ansiOffsets {
+		ansiOffsets[i] = r.ReadInt32()
+	}
+
+	wideOffsets := make([]int32, counts[6])
+	for i := range wideOffsets {
+		wideOffsets[i] = r.ReadInt32()
+	}
+
+	ansiData := r.Read(counts[7])
+	wideData := r.Read(counts[8] * 2)
+
+	store.ansiStrings = make([]string, len(ansiOffsets))
+	for i, offset := range ansiOffsets {
+		end := int(offset)
+		for end < len(ansiData) && ansiData[end] != 0 {
+			end++
+		}
+
+		store.ansiStrings[i] = string(ansiData[offset:end])
+	}
+
+	store.wideStrings = make([]string, len(wideOffsets))
+	for i, offset := range wideOffsets {
+		runes := make([]rune, 0)
+		for j := int(offset) * 2; j+1 < len(wideData); j += 2 {
+			char := uint16(wideData[j]) | uint16(wideData[j+1])<<8
+			if char == 0 {
+				break
+			}
+
+			runes = append(runes, rune(char))
+		}
+
+		store.wideStrings[i] = string(runes)
+	}
+
+	store.numberlessPairs = make([]fixedTagPair, counts[9])
+	for i := range store.numberlessPairs {
+		store.numberlessPairs[i] = fixedTagPair{
+			key:   r.nameAt(r.ReadInt32(), 0),
+			value: r.ReadUint32(),
+		}
+	}
+
+	store.pairs = make([]fixedTagPair, counts[10])
+	for i := range store.pairs {
+		store.pairs[i] = fixedTagPair{
+			key:   r.readName(),
+			value: r.ReadUint32(),
+		}
+	}
+
+	if magic := r.ReadUint32(); magic != endMagic {
+		panic(fmt.Sprintf("invalid tag store end magic: %x", magic))
+	}
+
+	return store
+}
+
+// tagMap resolves a map handle, packing whether its keys are numberless, its size and its first pair
+func (store *fixedTagStore) tagMap(handle uint64) map[string]string {
+	numberless := handle>>63 != 0
+	count := int(uint16(handle >> 32))
+	begin := int(uint32(handle))
+
+	pairs := store.pairs
+	if numberless {
+		pairs = store.numberlessPairs
+	}
+
+	tags := make(map[string]string, count)
+	for i := begin; i < begin+count && i < len(pairs); i++ {
+		tags[pairs[i].key] = store.value(pairs[i].value)
+	}
+
+	return tags
+}
+
+func (store *fixedTagStore) value(id uint32) string {
+	index := int(id >> 3)
+
+	var values []string
+	switch id & 0x7 {
+	case valueTypeAnsiString:
+		values = store.ansiStrings
+	case valueTypeWideString:
+		values = store.wideStrings
+	case valueTypeNumberlessName:
+		values = store.numberlessNames
+	case valueTypeName:
+		values = store.names
+	case valueTypeNumberlessExportPath:
+		values = store.numberlessExportPaths
+	case valueTypeExportPath:
+		values = store.exportPaths
+	case valueTypeLocalizedText:
+		values = store.texts
+	}
+
+	if index < 0 || index >= len(values) {
+		return ""
+	}
+
+	return values[index]
+}
+
+// exportPath formats an export path as UE does, Class'Package.Object'
+func exportPath(class string, object string, pkg string) string {
+	if class == "None" || class == "" {
+		return pkg + "." + object
+	}
+
+	return class + "'" + pkg + "." + object + "'"
+}
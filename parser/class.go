@@ -12,6 +12,21 @@ var classResolvers = map[string]ClassResolver{
 	"DataTable": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadUDataTable(ctx, uAsset)
 	},
+	"StringTable": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadUStringTable(ctx, uAsset)
+	},
+	"CurveTable": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadUCurveTable(ctx, uAsset)
+	},
+	"CurveFloat": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadUCurveFloat(ctx, uAsset)
+	},
+	"CurveVector": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadUCurveVector(ctx, uAsset)
+	},
+	"CurveLinearColor": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadUCurveLinearColor(ctx, uAsset)
+	},
 	"ObjectProperty": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
 		// TODO Figure out
 		parser.Read(24)
@@ -32,48 +47,29 @@ var classResolvers = map[string]ClassResolver{
 		parser.Read(24)
 		return parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports)
 	},
-	/*
-		"Texture2D": func(parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
-			// TODO Figure out
-			parser.Read(4)
-
-			// Some unknown flags
-			parser.Read(2)
-			parser.Read(2)
-
-			cooked := parser.ReadUint32()
-			textures := make([]*FTexturePlatformData, 0)
-
-			if cooked != 1 {
-				// Uncooked asset. No idea how it can exist.
-				return textures
-			}
-
-			pixelFormat := parser.ReadFName(uAsset.Names)
-
-			for strings.Trim(pixelFormat, "\x00") != "None" {
-				// TODO Unknown
-				parser.ReadInt64()
-				textures = append(textures, parser.ReadFTexturePlatformData(0))
-				pixelFormat = parser.ReadFName(uAsset.Names)
-			}
-
-			texture := Texture2D{
-				Cooked:   cooked,
-				Textures: textures,
-			}
-
-			img := texture.ToImage()
-
-			if img != nil {
-				f, _ := os.Create("paks/" + strings.Trim(export.ObjectName, "\x00") + ".png")
-				defer f.Close()
-				_ = png.Encode(f, img)
-			}
-
-			return texture
-		},
-	*/
+	"Texture2D": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadTexture2D(ctx, uAsset)
+	},
+	"TextureCube": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		texture := parser.ReadTexture2D(ctx, uAsset)
+		texture.IsCube = true
+		return texture
+	},
+	"Texture2DArray": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadTexture2D(ctx, uAsset)
+	},
+	"SoundWave": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadSoundWave(ctx, uAsset)
+	},
+	"StaticMesh": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadStaticMesh(ctx, uAsset)
+	},
+	"SkeletalMesh": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadSkeletalMesh(ctx, uAsset)
+	},
+	"Skeleton": func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadSkeleton(ctx, uAsset)
+	},
 }
 
 type ClassType struct {
@@ -92,7 +88,7 @@ func (parser *PakParser) ReadClass(ctx context.Context, export *FObjectExport, s
 
 	trimmedType := strings.Trim(className, "\x00")
 
-	resolver, ok := classResolvers[trimmedType]
+	resolver, ok := parser.registry.ClassResolver(trimmedType)
 
 	if !ok {
 		return nil, false
@@ -110,12 +106,13 @@ func (parser *PakParser) ReadClass(ctx context.Context, export *FObjectExport, s
 	log.Ctx(ctx).Warn().Msgf("Unread Class Type [%d]: %s", size, trimmedType)
 	// fmt.Println(utils.HexDump(data[offset:]))
 	if size > 0 {
-		parser.Read(size)
+		return parser.ReadRawData(size), true
 	}
 
 	return nil, true
 }
 
+// RegisterClassResolver registers a built-in resolver included in every default registry created afterwards
 func RegisterClassResolver(classType string, resolver ClassResolver) {
-	classResolvers[classType] = resolver
+	builtins.RegisterClassResolver(classType, resolver)
 }
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Curves/CurveFloat.h
+type UCurveFloat struct {
+	FloatCurve *FRichCurve `json:"float_curve"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Curves/CurveVector.h
+type UCurveVector struct {
+	FloatCurves [3]*FRichCurve `json:"float_curves"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Curves/CurveLinearColor.h
+type UCurveLinearColor struct {
+	FloatCurves [4]*FRichCurve `json:"float_curves"`
+}
+
+// The curves of curve assets are tagged properties, the class data only holds the UObject guid
+func (parser *PakParser) readCurveBase() {
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+}
+
+func (parser *PakParser) ReadUCurveFloat(ctx context.Context, uAsset *FPackageFileSummary) *UCurveFloat {
+	parser.readCurveBase()
+
+	return &UCurveFloat{
+		FloatCurve: parser.exportRichCurve("FloatCurve", 0),
+	}
+}
+
+func (parser *PakParser) ReadUCurveVector(ctx context.Context, uAsset *FPackageFileSummary) *UCurveVector {
+	parser.readCurveBase()
+
+	curve := &UCurveVector{}
+	for i := range curve.FloatCurves {
+		curve.FloatCurves[i] = parser.exportRichCurve("FloatCurves", int32(i))
+	}
+
+	return curve
+}
+
+func (parser *PakParser) ReadUCurveLinearColor(ctx context.Context, uAsset *FPackageFileSummary) *UCurveLinearColor {
+	parser.readCurveBase()
+
+	curve := &UCurveLinearColor{}
+	for i := range curve.FloatCurves {
+		curve.FloatCurves[i] = parser.exportRichCurve("FloatCurves", int32(i))
+	}
+
+	return curve
+}
+
+// exportRichCurve builds the curve of the export property at the static array index, an empty curve if it is missing
+func (parser *PakParser) exportRichCurve(name string, arrayIndex int32) *FRichCurve {
+	for _, property := range parser.exportProperties {
+		if strings.Trim(property.Name, "\x00") != name || property.ArrayIndex != arrayIndex {
+			continue
+		}
+
+		if properties, ok := property.Tag.([]*FPropertyTag); ok {
+			return NewFRichCurve(properties)
+		}
+	}
+
+	return NewFRichCurve(nil)
+}
+
+func (curve *UCurveFloat) Curves() ([]string, []Curve) {
+	return []string{"Value"}, []Curve{curve.FloatCurve}
+}
+
+func (curve *UCurveVector) Curves() ([]string, []Curve) {
+	return []string{"X", "Y", "Z"}, []Curve{curve.FloatCurves[0], curve.FloatCurves[1], curve.FloatCurves[2]}
+}
+
+func (curve *UCurveLinearColor) Curves() ([]string, []Curve) {
+	return []string{"R", "G", "B", "A"}, []Curve{curve.FloatCurves[0], curve.FloatCurves[1], curve.FloatCurves[2], curve.FloatCurves[3]}
+}
+
+// Curves returns the rows of the table sorted by name
+func (table *UCurveTable) Curves() ([]string, []Curve) {
+	names := make([]string, 0, len(table.RichCurves)+len(table.SimpleCurves))
+	for name := range table.RichCurves {
+		names = append(names, name)
+	}
+	for name := range table.SimpleCurves {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	curves := make([]Curve, len(names))
+	for i, name := range names {
+		if curve, ok := table.RichCurves[name]; ok {
+			curves[i] = curve
+		} else {
+			curves[i] = table.SimpleCurves[name]
+		}
+	}
+
+	return names, curves
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	CurveTableModeEmpty        = 0
+	CurveTableModeSimpleCurves = 1
+	CurveTableModeRichCurves   = 2
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/UObject/FortniteMainBranchObjectVersion.h
+var fortniteMainBranchObjectVersion = FGuid{A: 0x601D1886, B: 0xAC644F84, C: 0xAA16D3DE, D: 0x0DEAC7D6}
+
+// fortniteMainBranchShrinkCurveTableSize is FFortniteMainBranchObjectVersion::ShrinkCurveTableSize
+const fortniteMainBranchShrinkCurveTableSize = 23
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Engine/CurveTable.h
+type UCurveTable struct {
+	CurveTableMode uint8                    `json:"curve_table_mode"`
+	RichCurves     map[string]*FRichCurve   `json:"rich_curves,omitempty"`
+	SimpleCurves   map[string]*FSimpleCurve `json:"simple_curves,omitempty"`
+}
+
+func (parser *PakParser) ReadUCurveTable(ctx context.Context, uAsset *FPackageFileSummary) *UCurveTable {
+	// UObject guid
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+
+	rowCount := parser.ReadInt32()
+
+	table := &UCurveTable{}
+
+	version, ok := uAsset.CustomVersion(fortniteMainBranchObjectVersion)
+
+	if !ok && uAsset.Unversioned {
+		// Unversioned packages are saved with the latest custom versions of the engine
+		log.Ctx(ctx).Debug().Msg("Unversioned CurveTable, assuming it contains its curve table mode")
+		version, ok = fortniteMainBranchShrinkCurveTableSize, true
+	}
+
+	// Packages saved before simple curve rows were added only contain rich curves
+	if ok && version >= fortniteMainBranchShrinkCurveTableSize {
+		table.CurveTableMode = parser.Read(1)[0]
+	} else if rowCount > 0 {
+		table.CurveTableMode = CurveTableModeRichCurves
+	} else {
+		table.CurveTableMode = CurveTableModeEmpty
+	}
+
+	if table.CurveTableMode == CurveTableModeSimpleCurves {
+		table.SimpleCurves = make(map[string]*FSimpleCurve)
+	} else {
+		table.RichCurves = make(map[string]*FRichCurve)
+	}
+
+	for i := int32(0); i < rowCount; i++ {
+		name := strings.Trim(parser.ReadFName(uAsset.Names), "\x00")
+		properties := parser.ReadFPropertyTagLoop(ctx, uAsset)
+
+		if table.CurveTableMode == CurveTableModeSimpleCurves {
+			table.SimpleCurves[name] = NewFSimpleCurve(properties)
+		} else {
+			table.RichCurves[name] = NewFRichCurve(properties)
+		}
+	}
+
+	return table
+}
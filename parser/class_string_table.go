@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"context"
+	"strings"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Private/Internationalization/StringTableCore.cpp
+type UStringTable struct {
+	Namespace string                       `json:"namespace"`
+	Entries   map[string]string            `json:"entries"`
+	MetaData  map[string]map[string]string `json:"meta_data"`
+}
+
+func (parser *PakParser) ReadUStringTable(ctx context.Context, uAsset *FPackageFileSummary) *UStringTable {
+	// UObject guid
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+
+	table := &UStringTable{
+		Namespace: parser.ReadString(),
+		Entries:   make(map[string]string),
+		MetaData:  make(map[string]map[string]string),
+	}
+
+	entryCount := parser.ReadInt32()
+	for i := int32(0); i < entryCount; i++ {
+		key := parser.ReadString()
+		table.Entries[key] = parser.ReadString()
+	}
+
+	metaDataCount := parser.ReadInt32()
+	for i := int32(0); i < metaDataCount; i++ {
+		key := parser.ReadString()
+		metaData := make(map[string]string)
+
+		valueCount := parser.ReadInt32()
+		for j := int32(0); j < valueCount; j++ {
+			id := strings.Trim(parser.ReadFName(uAsset.Names), "\x00")
+			metaData[id] = parser.ReadString()
+		}
+
+		table.MetaData[key] = metaData
+	}
+
+	return table
+}
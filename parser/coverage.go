@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+type CoverageStatus uint8
+
+const (
+	CoverageDecoded CoverageStatus = iota
+	CoveragePartial
+	CoverageSkipped
+)
+
+type CoverageStats struct {
+	Decoded      int   `json:"decoded"`
+	DecodedBytes int64 `json:"decoded_bytes"`
+	Partial      int   `json:"partial"`
+	PartialBytes int64 `json:"partial_bytes"`
+	Skipped      int   `json:"skipped"`
+	SkippedBytes int64 `json:"skipped_bytes"`
+}
+
+// Coverage aggregates how much of the processed exports and structs were understood by the parser
+type Coverage struct {
+	Classes map[string]*CoverageStats `json:"classes"`
+	Structs map[string]*CoverageStats `json:"structs"`
+}
+
+type CoverageRow struct {
+	Name  string         `json:"name"`
+	Stats *CoverageStats `json:"stats"`
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{
+		Classes: make(map[string]*CoverageStats),
+		Structs: make(map[string]*CoverageStats),
+	}
+}
+
+func (stats *CoverageStats) Add(status CoverageStatus, count int, bytes int64) {
+	switch status {
+	case CoverageDecoded:
+		stats.Decoded += count
+		stats.DecodedBytes += bytes
+	case CoveragePartial:
+		stats.Partial += count
+		stats.PartialBytes += bytes
+	case CoverageSkipped:
+		stats.Skipped += count
+		stats.SkippedBytes += bytes
+	}
+}
+
+// UndecodedBytes is the amount of bytes that were not fully understood
+func (stats *CoverageStats) UndecodedBytes() int64 {
+	return stats.PartialBytes + stats.SkippedBytes
+}
+
+// AddEntry records every export of the entry. Exports that failed to parse entirely or have no class resolver are counted as skipped.
+func (coverage *Coverage) AddEntry(entry *PakEntrySet) {
+	if len(entry.Exports) == 0 {
+		for _, export := range entry.Summary.Exports {
+			coverage.stats(coverage.Classes, exportClassName(export)).Add(CoverageSkipped, 1, export.SerialSize)
+		}
+
+		return
+	}
+
+	for _, exportSet := range entry.Exports {
+		className := exportClassName(exportSet.Export)
+
+		if exportSet.Data == nil {
+			coverage.stats(coverage.Classes, className).Add(CoverageSkipped, 1, exportSet.Export.SerialSize)
+			continue
+		}
+
+		nestedRaw := coverage.walkProperties(exportSet.Data.Properties)
+		nestedRaw = coverage.walkValue(exportSet.Data.Data) || nestedRaw
+
+		status := CoverageDecoded
+		if exportSet.Data.Unresolved {
+			status = CoverageSkipped
+		} else if exportSet.Data.Raw != nil || nestedRaw {
+			status = CoveragePartial
+		}
+
+		coverage.stats(coverage.Classes, className).Add(status, 1, exportSet.Export.SerialSize)
+	}
+}
+
+// ClassRows returns the class statistics ordered by the amount of undecoded bytes
+func (coverage *Coverage) ClassRows() []CoverageRow {
+	return sortedCoverageRows(coverage.Classes)
+}
+
+// StructRows returns the struct statistics ordered by the amount of undecoded bytes
+func (coverage *Coverage) StructRows() []CoverageRow {
+	return sortedCoverageRows(coverage.Structs)
+}
+
+func (coverage *Coverage) stats(target map[string]*CoverageStats, name string) *CoverageStats {
+	stats, ok := target[name]
+
+	if !ok {
+		stats = &CoverageStats{}
+		target[name] = stats
+	}
+
+	return stats
+}
+
+// walkProperties records all struct properties and returns whether any raw data was found
+func (coverage *Coverage) walkProperties(properties []*FPropertyTag) bool {
+	hasRaw := false
+
+	for _, property := range properties {
+		if property == nil {
+			continue
+		}
+
+		raw := property.Raw != nil
+		nestedRaw := coverage.walkValue(property.Tag)
+
+		if structData, ok := property.TagData.(*StructProperty); ok {
+			status := coverageStatus(raw, nestedRaw, property.Tag != nil)
+			coverage.stats(coverage.Structs, strings.Trim(structData.Type, "\x00")).Add(status, 1, int64(property.Size))
+		}
+
+		if values, ok := property.Tag.([]interface{}); ok && len(values) > 0 {
+			if element, ok := values[0].(*ArrayStructProperty); ok && element.InnerTagData != nil {
+				inner := element.InnerTagData
+				if structData, ok := inner.TagData.(*StructProperty); ok {
+					status := coverageStatus(inner.Raw != nil, nestedRaw, element.Properties != nil)
+					coverage.stats(coverage.Structs, strings.Trim(structData.Type, "\x00")).Add(status, len(values), int64(inner.Size))
+				}
+
+				raw = raw || inner.Raw != nil
+			}
+		}
+
+		hasRaw = hasRaw || raw || nestedRaw
+	}
+
+	return hasRaw
+}
+
+// walkValue descends into decoded tag values and returns whether any raw data was found
+func (coverage *Coverage) walkValue(value interface{}) bool {
+	switch v := value.(type) {
+	case *RawData:
+		return true
+	case []*FPropertyTag:
+		return coverage.walkProperties(v)
+	case *StructType:
+		return coverage.walkValue(v.Value)
+	case *ArrayStructProperty:
+		return coverage.walkValue(v.Properties)
+	case []interface{}:
+		hasRaw := false
+		for _, element := range v {
+			hasRaw = coverage.walkValue(element) || hasRaw
+		}
+		return hasRaw
+	case []*MapPropertyEntry:
+		hasRaw := false
+		for _, entry := range v {
+			if entry == nil {
+				continue
+			}
+			hasRaw = coverage.walkValue(entry.Key) || hasRaw
+			hasRaw = coverage.walkValue(entry.Value) || hasRaw
+		}
+		return hasRaw
+	}
+
+	return false
+}
+
+func coverageStatus(raw bool, nestedRaw bool, decoded bool) CoverageStatus {
+	if !raw && !nestedRaw {
+		return CoverageDecoded
+	}
+
+	if !decoded {
+		return CoverageSkipped
+	}
+
+	return CoveragePartial
+}
+
+func exportClassName(export *FObjectExport) string {
+	if export.TemplateIndex != nil {
+		if className := export.TemplateIndex.ClassName(); className != nil {
+			return strings.Trim(*className, "\x00")
+		}
+	}
+
+	return "Unknown"
+}
+
+func sortedCoverageRows(target map[string]*CoverageStats) []CoverageRow {
+	rows := make([]CoverageRow, 0, len(target))
+
+	for name, stats := range target {
+		rows = append(rows, CoverageRow{
+			Name:  name,
+			Stats: stats,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Stats.UndecodedBytes() != rows[j].Stats.UndecodedBytes() {
+			return rows[i].Stats.UndecodedBytes() > rows[j].Stats.UndecodedBytes()
+		}
+
+		return rows[i].Name < rows[j].Name
+	})
+
+	return rows
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+)
+
+// PreloadEncrypted reads n AES encrypted bytes and preloads the decrypted data.
+// The key is picked from the parser's keys the first time encrypted data is read.
+func (parser *PakParser) PreloadEncrypted(n int32) {
+	if n%aes.BlockSize != 0 {
+		panic(fmt.Sprintf("Encrypted data is not aligned to the AES block size: %d", n))
+	}
+
+	buffer := make([]byte, n)
+	read, err := parser.reader.Read(buffer)
+
+	if err != nil {
+		panic(err)
+	}
+
+	if int32(read) < n {
+		panic(fmt.Sprintf("End of stream: %d < %d", read, n))
+	}
+
+	if parser.cipher == nil {
+		parser.cipher = parser.findIndexCipher(buffer)
+	}
+
+	decryptECB(parser.cipher, buffer, buffer)
+
+	if parser.preload != nil && len(parser.preload) > 0 {
+		parser.preload = append(parser.preload, buffer...)
+	} else {
+		parser.preload = buffer
+	}
+}
+
+// findIndexCipher returns the cipher for the first key that decrypts the index into a valid mount point
+func (parser *PakParser) findIndexCipher(index []byte) cipher.Block {
+	decrypted := make([]byte, len(index))
+
+	for _, key := range parser.aesKeys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			continue
+		}
+
+		decryptECB(block, decrypted, index)
+
+		if isValidMountPoint(decrypted) {
+			return block
+		}
+	}
+
+	panic(fmt.Sprintf("Pak index is encrypted and none of the %d AES keys match", len(parser.aesKeys)))
+}
+
+func isValidMountPoint(index []byte) bool {
+	if len(index) < 4 {
+		return false
+	}
+
+	length := int32(binary.LittleEndian.Uint32(index))
+
+	if length <= 0 || length > 1024 || int(length)+4 > len(index) {
+		return false
+	}
+
+	return index[4+length-1] == 0
+}
+
+// UE4 encrypts pak data using AES-256 in ECB mode
+func decryptECB(block cipher.Block, dst []byte, src []byte) {
+	for i := 0; i < len(src); i += aes.BlockSize {
+		block.Decrypt(dst[i:i+aes.BlockSize], src[i:i+aes.BlockSize])
+	}
+}
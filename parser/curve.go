@@ -0,0 +1,360 @@
+package parser
+
+import "math"
+
+const (
+	RichCurveInterpModeLinear   = 0
+	RichCurveInterpModeConstant = 1
+	RichCurveInterpModeCubic    = 2
+
+	RichCurveTangentWeightModeNone   = 0
+	RichCurveTangentWeightModeArrive = 1
+	RichCurveTangentWeightModeLeave  = 2
+	RichCurveTangentWeightModeBoth   = 3
+)
+
+// Curve is a curve that can be sampled
+type Curve interface {
+	// Eval returns the value of the curve at the time, defaultValue if the curve has no keys and no default value
+	Eval(time float32, defaultValue float32) float32
+
+	// TimeRange returns the times of the first and last key
+	TimeRange() (float32, float32)
+}
+
+// CurveAsset is implemented by exports holding named curves
+type CurveAsset interface {
+	Curves() ([]string, []Curve)
+}
+
+// curveKeys is the common part of the keys of rich and simple curves
+type curveKeys interface {
+	count() int
+	time(index int) float32
+	value(index int) float32
+}
+
+type richCurveKeys []*FRichCurveKey
+
+func (keys richCurveKeys) count() int              { return len(keys) }
+func (keys richCurveKeys) time(index int) float32  { return keys[index].Time }
+func (keys richCurveKeys) value(index int) float32 { return keys[index].Value }
+
+type simpleCurveKeys []*FSimpleCurveKey
+
+func (keys simpleCurveKeys) count() int              { return len(keys) }
+func (keys simpleCurveKeys) time(index int) float32  { return keys[index].Time }
+func (keys simpleCurveKeys) value(index int) float32 { return keys[index].Value }
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/Curves/RichCurve.cpp
+func (curve *FRichCurve) Eval(time float32, defaultValue float32) float32 {
+	return evalCurve(richCurveKeys(curve.Keys), curve.PreInfinityExtrap, curve.PostInfinityExtrap, curve.DefaultValue, time, defaultValue, func(index int, time float32) float32 {
+		previous, next := curve.Keys[index-1], curve.Keys[index]
+		diff := next.Time - previous.Time
+
+		if diff <= 0 || previous.InterpMode == RichCurveInterpModeConstant {
+			return previous.Value
+		}
+
+		alpha := (time - previous.Time) / diff
+
+		if previous.InterpMode == RichCurveInterpModeLinear {
+			return lerp(previous.Value, next.Value, alpha)
+		}
+
+		if isUnweighted(previous.TangentWeightMode, RichCurveTangentWeightModeArrive) && isUnweighted(next.TangentWeightMode, RichCurveTangentWeightModeLeave) {
+			p1 := previous.Value + previous.LeaveTangent*diff/3
+			p2 := next.Value - next.ArriveTangent*diff/3
+			return bezierInterp(previous.Value, p1, p2, next.Value, alpha)
+		}
+
+		return weightedEvalForTwoKeys(previous, next, time)
+	})
+}
+
+func (curve *FRichCurve) TimeRange() (float32, float32) {
+	return curveTimeRange(richCurveKeys(curve.Keys))
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/Curves/SimpleCurve.cpp
+func (curve *FSimpleCurve) Eval(time float32, defaultValue float32) float32 {
+	return evalCurve(simpleCurveKeys(curve.Keys), curve.PreInfinityExtrap, curve.PostInfinityExtrap, curve.DefaultValue, time, defaultValue, func(index int, time float32) float32 {
+		previous, next := curve.Keys[index-1], curve.Keys[index]
+		diff := next.Time - previous.Time
+
+		if diff <= 0 || curve.InterpMode == "RCIM_Constant" {
+			return previous.Value
+		}
+
+		return lerp(previous.Value, next.Value, (time-previous.Time)/diff)
+	})
+}
+
+func (curve *FSimpleCurve) TimeRange() (float32, float32) {
+	return curveTimeRange(simpleCurveKeys(curve.Keys))
+}
+
+func curveTimeRange(keys curveKeys) (float32, float32) {
+	if keys.count() == 0 {
+		return 0, 0
+	}
+
+	return keys.time(0), keys.time(keys.count() - 1)
+}
+
+// evalCurve handles the extrapolation shared by rich and simple curves, interpolate evaluating between the keys index-1 and index
+func evalCurve(keys curveKeys, preInfinityExtrap string, postInfinityExtrap string, curveDefault *float32, time float32, defaultValue float32, interpolate func(index int, time float32) float32) float32 {
+	count := keys.count()
+
+	if count == 0 {
+		if curveDefault != nil {
+			return *curveDefault
+		}
+
+		return defaultValue
+	}
+
+	time, valueOffset := remapCurveTime(keys, preInfinityExtrap, postInfinityExtrap, time)
+
+	first, last := 0, count-1
+
+	if count < 2 || time <= keys.time(first) {
+		if preInfinityExtrap == "RCCE_Linear" && count > 1 {
+			return extrapolateLinear(keys, first, first+1, time) + valueOffset
+		}
+
+		return keys.value(first) + valueOffset
+	}
+
+	if time >= keys.time(last) {
+		if postInfinityExtrap == "RCCE_Linear" {
+			return extrapolateLinear(keys, last, last-1, time) + valueOffset
+		}
+
+		return keys.value(last) + valueOffset
+	}
+
+	// Find the first key after the time
+	index := 1 + sortSearch(count-1, func(i int) bool {
+		return time < keys.time(i+1)
+	})
+
+	return interpolate(index, time) + valueOffset
+}
+
+// remapCurveTime maps a time outside of the keys into them for the cycling extrapolation modes, returning the value offset of cycles with offset
+func remapCurveTime(keys curveKeys, preInfinityExtrap string, postInfinityExtrap string, time float32) (float32, float32) {
+	count := keys.count()
+
+	if count < 2 {
+		return time, 0
+	}
+
+	extrap := postInfinityExtrap
+	if time <= keys.time(0) {
+		extrap = preInfinityExtrap
+	} else if time < keys.time(count-1) {
+		return time, 0
+	}
+
+	if extrap == "RCCE_Linear" || extrap == "RCCE_Constant" {
+		return time, 0
+	}
+
+	minTime, maxTime := keys.time(0), keys.time(count-1)
+	duration := maxTime - minTime
+	initialTime := time
+	cycles := 0
+
+	if time > maxTime {
+		cycles = int(math.Floor(float64((maxTime - time) / duration)))
+		time += duration * float32(cycles)
+	} else if time < minTime {
+		cycles = int(math.Floor(float64((time - minTime) / duration)))
+		time -= duration * float32(cycles)
+	}
+
+	if time == maxTime && initialTime < minTime {
+		time = minTime
+	}
+
+	if time == minTime && initialTime > maxTime {
+		time = maxTime
+	}
+
+	if cycles < 0 {
+		cycles = -cycles
+	}
+
+	switch extrap {
+	case "RCCE_CycleWithOffset":
+		offset := keys.value(count-1) - keys.value(0)
+		if initialTime <= minTime {
+			offset = -offset
+		}
+
+		return time, offset * float32(cycles)
+	case "RCCE_Oscillate":
+		if cycles%2 == 1 {
+			time = minTime + (maxTime - time)
+		}
+	}
+
+	return time, 0
+}
+
+func extrapolateLinear(keys curveKeys, from int, to int, time float32) float32 {
+	dt := keys.time(to) - keys.time(from)
+
+	if math.Abs(float64(dt)) < 1e-8 {
+		return keys.value(from)
+	}
+
+	slope := (keys.value(to) - keys.value(from)) / dt
+
+	return slope*(time-keys.time(from)) + keys.value(from)
+}
+
+// sortSearch returns the smallest index in [0, n) for which f is true, n if there is none
+func sortSearch(n int, f func(int) bool) int {
+	low, high := 0, n
+
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+
+		if !f(middle) {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+
+	return low
+}
+
+// isUnweighted reports whether the tangent weight mode does not weight the tangent on the side of other
+func isUnweighted(mode uint8, other uint8) bool {
+	return mode == RichCurveTangentWeightModeNone || mode == other
+}
+
+func lerp(a float32, b float32, alpha float32) float32 {
+	return a + alpha*(b-a)
+}
+
+func bezierInterp(p0 float32, p1 float32, p2 float32, p3 float32, alpha float32) float32 {
+	p01 := lerp(p0, p1, alpha)
+	p12 := lerp(p1, p2, alpha)
+	p23 := lerp(p2, p3, alpha)
+
+	return lerp(lerp(p01, p12, alpha), lerp(p12, p23, alpha), alpha)
+}
+
+// weightedEvalForTwoKeys evaluates a cubic segment with weighted tangents, solving the bezier for the time first
+func weightedEvalForTwoKeys(key1 *FRichCurveKey, key2 *FRichCurveKey, time float32) float32 {
+	diff := float64(key2.Time - key1.Time)
+	alpha := (float64(time) - float64(key1.Time)) / diff
+
+	leaveWeight := float64(key1.LeaveTangentWeight)
+	if isUnweighted(key1.TangentWeightMode, RichCurveTangentWeightModeArrive) {
+		y := float64(key1.LeaveTangent) * diff
+		leaveWeight = math.Sqrt(diff*diff+y*y) / 3
+	}
+
+	angle := math.Atan(float64(key1.LeaveTangent))
+	key1TanX := math.Cos(angle)*leaveWeight + float64(key1.Time)
+	key1TanY := math.Sin(angle)*leaveWeight + float64(key1.Value)
+
+	arriveWeight := float64(key2.ArriveTangentWeight)
+	if isUnweighted(key2.TangentWeightMode, RichCurveTangentWeightModeLeave) {
+		y := float64(key2.ArriveTangent) * diff
+		arriveWeight = math.Sqrt(diff*diff+y*y) / 3
+	}
+
+	angle = math.Atan(float64(key2.ArriveTangent))
+	key2TanX := -math.Cos(angle)*arriveWeight + float64(key2.Time)
+	key2TanY := -math.Sin(angle)*arriveWeight + float64(key2.Value)
+
+	normalizedX1 := (key1TanX - float64(key1.Time)) / diff
+	normalizedX2 := (key2TanX - float64(key1.Time)) / diff
+
+	// Convert the bezier of the times to the power basis and solve it for alpha
+	a := normalizedX1
+	b := normalizedX2 - normalizedX1
+	c := 1 - normalizedX2
+	d := b - a
+
+	solutions := solveCubic(c-b-d, 3*d, 3*a, -alpha)
+
+	interp := alpha
+	if len(solutions) == 1 {
+		interp = solutions[0]
+	} else if len(solutions) > 1 {
+		interp = -1
+
+		for _, solution := range solutions {
+			if solution >= 0 && solution <= 1 && solution > interp {
+				interp = solution
+			}
+		}
+
+		if interp < 0 {
+			interp = 0
+		}
+	}
+
+	return bezierInterp(key1.Value, float32(key1TanY), float32(key2TanY), key2.Value, float32(interp))
+}
+
+// solveCubic returns the real roots of a*x^3 + b*x^2 + c*x + d
+func solveCubic(a float64, b float64, c float64, d float64) []float64 {
+	const epsilon = 1e-8
+
+	if math.Abs(a) < epsilon {
+		if math.Abs(b) < epsilon {
+			if math.Abs(c) < epsilon {
+				return nil
+			}
+
+			return []float64{-d / c}
+		}
+
+		discriminant := c*c - 4*b*d
+		if discriminant < 0 {
+			return nil
+		}
+
+		root := math.Sqrt(discriminant)
+		return []float64{(-c + root) / (2 * b), (-c - root) / (2 * b)}
+	}
+
+	// Normal form x^3 + Ax^2 + Bx + C
+	A, B, C := b/a, c/a, d/a
+
+	p := (-A*A/3 + B) / 3
+	q := (2*A*A*A/27 - A*B/3 + C) / 2
+	discriminant := q*q + p*p*p
+
+	var solutions []float64
+
+	if math.Abs(discriminant) < epsilon {
+		if math.Abs(q) < epsilon {
+			solutions = []float64{0}
+		} else {
+			u := math.Cbrt(-q)
+			solutions = []float64{2 * u, -u}
+		}
+	} else if discriminant < 0 {
+		phi := math.Acos(-q/math.Sqrt(-p*p*p)) / 3
+		t := 2 * math.Sqrt(-p)
+		solutions = []float64{t * math.Cos(phi), -t * math.Cos(phi+math.Pi/3), -t * math.Cos(phi-math.Pi/3)}
+	} else {
+		root := math.Sqrt(discriminant)
+		solutions = []float64{math.Cbrt(root-q) - math.Cbrt(root+q)}
+	}
+
+	for i := range solutions {
+		solutions[i] -= A / 3
+	}
+
+	return solutions
+}
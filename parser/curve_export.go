@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+// WriteCurvesCSV samples the curves of the asset at every step between their first and last key as CSV
+func WriteCurvesCSV(w io.Writer, asset CurveAsset, step float32) error {
+	names, curves := asset.Curves()
+
+	start, end := float32(math.MaxFloat32), float32(-math.MaxFloat32)
+	for _, curve := range curves {
+		first, last := curve.TimeRange()
+		start = float32(math.Min(float64(start), float64(first)))
+		end = float32(math.Max(float64(end), float64(last)))
+	}
+
+	if start > end {
+		start, end = 0, 0
+	}
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(append([]string{"Time"}, names...)); err != nil {
+		return err
+	}
+
+	writeSample := func(time float32) error {
+		row := make([]string, len(curves)+1)
+		row[0] = strconv.FormatFloat(float64(time), 'g', -1, 32)
+
+		for i, curve := range curves {
+			row[i+1] = strconv.FormatFloat(float64(curve.Eval(time, 0)), 'g', -1, 32)
+		}
+
+		return writer.Write(row)
+	}
+
+	sampleCount := int(math.Floor(float64((end-start)/step))) + 1
+	lastTime := start
+
+	for i := 0; i < sampleCount; i++ {
+		lastTime = start + float32(i)*step
+
+		if err := writeSample(lastTime); err != nil {
+			return err
+		}
+	}
+
+	// The last key is always sampled, even if it does not fall on a step
+	if lastTime < end {
+		if err := writeSample(end); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"math"
+	"testing"
+)
+
+func linearKeys(mode uint8) []*FRichCurveKey {
+	return []*FRichCurveKey{
+		{InterpMode: mode, Time: 0, Value: 0},
+		{InterpMode: mode, Time: 1, Value: 1},
+	}
+}
+
+func TestRichCurveEval(t *testing.T) {
+	defaultValue := float32(5)
+
+	tests := []struct {
+		name  string
+		curve *FRichCurve
+		time  float32
+		want  float32
+	}{
+		{"linear", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear)}, 0.25, 0.25},
+		{"constant", &FRichCurve{Keys: linearKeys(RichCurveInterpModeConstant)}, 0.75, 0},
+		{"cubic flat tangents", &FRichCurve{Keys: linearKeys(RichCurveInterpModeCubic)}, 0.25, 0.15625},
+		{"cubic flat tangents midpoint", &FRichCurve{Keys: linearKeys(RichCurveInterpModeCubic)}, 0.5, 0.5},
+		{"cubic straight tangents", &FRichCurve{Keys: []*FRichCurveKey{
+			{InterpMode: RichCurveInterpModeCubic, Time: 0, Value: 0, LeaveTangent: 1},
+			{InterpMode: RichCurveInterpModeCubic, Time: 1, Value: 1, ArriveTangent: 1},
+		}}, 0.25, 0.25},
+		{"weighted default weights", &FRichCurve{Keys: []*FRichCurveKey{
+			{InterpMode: RichCurveInterpModeCubic, TangentWeightMode: RichCurveTangentWeightModeBoth, Time: 0, Value: 0, LeaveTangentWeight: 1.0 / 3},
+			{InterpMode: RichCurveInterpModeCubic, TangentWeightMode: RichCurveTangentWeightModeBoth, Time: 1, Value: 1, ArriveTangentWeight: 1.0 / 3},
+		}}, 0.25, 0.15625},
+		{"weighted zero weights", &FRichCurve{Keys: []*FRichCurveKey{
+			{InterpMode: RichCurveInterpModeCubic, TangentWeightMode: RichCurveTangentWeightModeBoth, Time: 0, Value: 0},
+			{InterpMode: RichCurveInterpModeCubic, TangentWeightMode: RichCurveTangentWeightModeBoth, Time: 1, Value: 1},
+		}}, 0.25, 0.25},
+		{"post constant", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PostInfinityExtrap: "RCCE_Constant"}, 2, 1},
+		{"post linear", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PostInfinityExtrap: "RCCE_Linear"}, 2, 2},
+		{"pre linear", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PreInfinityExtrap: "RCCE_Linear"}, -0.5, -0.5},
+		{"post cycle", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PostInfinityExtrap: "RCCE_Cycle"}, 1.25, 0.25},
+		{"pre cycle", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PreInfinityExtrap: "RCCE_Cycle"}, -0.75, 0.25},
+		{"post cycle with offset", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PostInfinityExtrap: "RCCE_CycleWithOffset"}, 1.25, 1.25},
+		{"pre cycle with offset", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PreInfinityExtrap: "RCCE_CycleWithOffset"}, -0.75, -0.75},
+		{"post oscillate odd cycle", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PostInfinityExtrap: "RCCE_Oscillate"}, 1.25, 0.75},
+		{"post oscillate even cycle", &FRichCurve{Keys: linearKeys(RichCurveInterpModeLinear), PostInfinityExtrap: "RCCE_Oscillate"}, 2.25, 0.25},
+		{"empty", &FRichCurve{}, 0, 3},
+		{"empty with default", &FRichCurve{DefaultValue: &defaultValue}, 0, 5},
+	}
+
+	for _, test := range tests {
+		if got := test.curve.Eval(test.time, 3); math.Abs(float64(got-test.want)) > 1e-4 {
+			t.Errorf("%s: Eval(%v) = %v, want %v", test.name, test.time, got, test.want)
+		}
+	}
+}
+
+func TestSimpleCurveEval(t *testing.T) {
+	keys := []*FSimpleCurveKey{{Time: 0, Value: 0}, {Time: 2, Value: 1}}
+
+	tests := []struct {
+		name  string
+		curve *FSimpleCurve
+		time  float32
+		want  float32
+	}{
+		{"linear", &FSimpleCurve{Keys: keys, InterpMode: "RCIM_Linear"}, 1, 0.5},
+		{"constant", &FSimpleCurve{Keys: keys, InterpMode: "RCIM_Constant"}, 1.5, 0},
+		{"post cycle", &FSimpleCurve{Keys: keys, InterpMode: "RCIM_Linear", PostInfinityExtrap: "RCCE_Cycle"}, 3, 0.5},
+		{"pre oscillate", &FSimpleCurve{Keys: keys, InterpMode: "RCIM_Linear", PreInfinityExtrap: "RCCE_Oscillate"}, -0.5, 0.25},
+	}
+
+	for _, test := range tests {
+		if got := test.curve.Eval(test.time, 0); math.Abs(float64(got-test.want)) > 1e-4 {
+			t.Errorf("%s: Eval(%v) = %v, want %v", test.name, test.time, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,405 @@
+package parser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	DependencyHard = "hard"
+	DependencySoft = "soft"
+)
+
+// DependencyGraph collects the packages referenced by every processed package across paks
+type DependencyGraph struct {
+	// Dependencies maps a package to the packages it references and whether they are hard or soft references
+	Dependencies map[string]map[string]string
+
+	known map[string]bool
+}
+
+type DependencyNode struct {
+	Name    string `json:"name"`
+	Missing bool   `json:"missing"`
+}
+
+type DependencyEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Kind   string `json:"kind"`
+	Broken bool   `json:"broken"`
+}
+
+// DependencyReport is the part of the graph selected by DependencyReportOptions
+type DependencyReport struct {
+	Reverse bool              `json:"reverse"`
+	Nodes   []*DependencyNode `json:"nodes"`
+	Edges   []*DependencyEdge `json:"edges"`
+}
+
+type DependencyReportOptions struct {
+	// Reverse points the edges from the dependencies to the packages referencing them
+	Reverse bool
+
+	// Roots selects the packages the report starts from, following the edges. All packages are included when nil.
+	Roots func(string) bool
+
+	// Depth limits how many edges are followed from the roots, zero meaning no limit
+	Depth int
+
+	// BrokenOnly keeps only the references to packages absent from every pak
+	BrokenOnly bool
+}
+
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		Dependencies: make(map[string]map[string]string),
+		known:        make(map[string]bool),
+	}
+}
+
+// PackageName converts the path of a file inside a pak to the name of its package, e.g. /Game/Path/Asset
+func PackageName(fileName string) string {
+	fileName = strings.Trim(fileName, "\x00")
+	name := "/" + strings.TrimLeft(strings.TrimSuffix(fileName, path.Ext(fileName)), "./")
+
+	index := strings.Index(name, "/Content/")
+	if index < 0 {
+		return name
+	}
+
+	root := path.Base(name[:index])
+	if root != "Engine" && !strings.Contains(name[:index], "/Plugins/") {
+		// Content of the project itself
+		root = "Game"
+	}
+
+	return "/" + root + "/" + name[index+len("/Content/"):]
+}
+
+// AddFile registers a file of a pak, marking its package as present
+func (graph *DependencyGraph) AddFile(fileName string) {
+	switch path.Ext(strings.Trim(fileName, "\x00")) {
+	case ".uasset", ".umap":
+		graph.known[PackageName(fileName)] = true
+	}
+}
+
+// AddEntry records the imported packages, soft package references and soft object paths of the entry
+func (graph *DependencyGraph) AddEntry(entry *PakEntrySet) {
+	name := PackageName(entry.ExportRecord.FileName)
+	graph.known[name] = true
+
+	dependencies, ok := graph.Dependencies[name]
+	if !ok {
+		dependencies = make(map[string]string)
+		graph.Dependencies[name] = dependencies
+	}
+
+	add := func(dependency string, kind string) {
+		dependency = strings.Trim(dependency, "\x00")
+
+		// Drop the object name of object paths
+		if index := strings.Index(dependency, "."); index >= 0 {
+			dependency = dependency[:index]
+		}
+
+		if dependency == "" || dependency == "None" || dependency == name {
+			return
+		}
+
+		// A hard reference always wins over a soft one
+		if dependencies[dependency] != DependencyHard {
+			dependencies[dependency] = kind
+		}
+	}
+
+	for _, objectImport := range entry.Summary.Imports {
+		if strings.Trim(objectImport.ClassName, "\x00") == "Package" && objectImport.OuterIndex == 0 {
+			add(objectImport.ObjectName, DependencyHard)
+		}
+	}
+
+	for _, reference := range entry.Summary.SoftPackageReferences {
+		add(reference, DependencySoft)
+	}
+
+	softPaths := make([]*FSoftObjectPath, 0)
+	for _, exportSet := range entry.Exports {
+		if exportSet.Data == nil {
+			continue
+		}
+
+		softPaths = collectSoftObjectPaths(exportSet.Data.Properties, softPaths)
+		softPaths = collectSoftObjectPaths(exportSet.Data.Data, softPaths)
+	}
+
+	for _, softPath := range softPaths {
+		add(softPath.AssetPathName, DependencySoft)
+	}
+}
+
+// Missing returns whether the package is absent from every pak. Native /Script/ packages are never missing.
+func (graph *DependencyGraph) Missing(name string) bool {
+	return !graph.known[name] && !strings.HasPrefix(name, "/Script/")
+}
+
+// Report selects the edges matching the options, ordered by name
+func (graph *DependencyGraph) Report(options DependencyReportOptions) *DependencyReport {
+	outgoing := make(map[string][]*DependencyEdge)
+
+	for from, dependencies := range graph.Dependencies {
+		for to, kind := range dependencies {
+			edge := &DependencyEdge{
+				From:   from,
+				To:     to,
+				Kind:   kind,
+				Broken: graph.Missing(to),
+			}
+
+			if options.BrokenOnly && !edge.Broken {
+				continue
+			}
+
+			if options.Reverse {
+				edge.From, edge.To = edge.To, edge.From
+			}
+
+			outgoing[edge.From] = append(outgoing[edge.From], edge)
+		}
+	}
+
+	edges := make([]*DependencyEdge, 0)
+
+	if options.Roots == nil {
+		for _, nodeEdges := range outgoing {
+			edges = append(edges, nodeEdges...)
+		}
+	} else {
+		// Breadth first from the roots, so the depth of every package is the shortest one
+		depths := make(map[string]int)
+		queue := make([]string, 0)
+
+		for name := range outgoing {
+			if options.Roots(name) {
+				depths[name] = 0
+				queue = append(queue, name)
+			}
+		}
+
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+
+			if options.Depth > 0 && depths[name] >= options.Depth {
+				continue
+			}
+
+			for _, edge := range outgoing[name] {
+				edges = append(edges, edge)
+
+				if _, visited := depths[edge.To]; !visited {
+					depths[edge.To] = depths[name] + 1
+					queue = append(queue, edge.To)
+				}
+			}
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+
+		return edges[i].To < edges[j].To
+	})
+
+	report := &DependencyReport{
+		Reverse: options.Reverse,
+		Nodes:   make([]*DependencyNode, 0),
+		Edges:   edges,
+	}
+
+	nodes := make(map[string]bool)
+	for _, edge := range edges {
+		for _, name := range []string{edge.From, edge.To} {
+			if !nodes[name] {
+				nodes[name] = true
+				report.Nodes = append(report.Nodes, &DependencyNode{
+					Name:    name,
+					Missing: graph.Missing(name),
+				})
+			}
+		}
+	}
+
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		return report.Nodes[i].Name < report.Nodes[j].Name
+	})
+
+	return report
+}
+
+// WriteDOT writes the report as a Graphviz graph. Missing packages are red and soft references dashed.
+func (report *DependencyReport) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph dependencies {"); err != nil {
+		return err
+	}
+
+	for _, node := range report.Nodes {
+		attributes := ""
+		if node.Missing {
+			attributes = " [color=red]"
+		}
+
+		if _, err := fmt.Fprintf(w, "  %q%s;\n", node.Name, attributes); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range report.Edges {
+		attributes := make([]string, 0)
+		if edge.Kind == DependencySoft {
+			attributes = append(attributes, "style=dashed")
+		}
+
+		if edge.Broken {
+			attributes = append(attributes, "color=red")
+		}
+
+		suffix := ""
+		if len(attributes) > 0 {
+			suffix = " [" + strings.Join(attributes, ", ") + "]"
+		}
+
+		if _, err := fmt.Fprintf(w, "  %q -> %q%s;\n", edge.From, edge.To, suffix); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// http://graphml.graphdrawing.org/specification.html
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the report as a GraphML document
+func (report *DependencyReport) WriteGraphML(w io.Writer) error {
+	document := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "missing", For: "node", AttrName: "missing", AttrType: "boolean"},
+			{ID: "kind", For: "edge", AttrName: "kind", AttrType: "string"},
+			{ID: "broken", For: "edge", AttrName: "broken", AttrType: "boolean"},
+		},
+		Graph: graphMLGraph{
+			ID:          "dependencies",
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, len(report.Nodes)),
+			Edges:       make([]graphMLEdge, len(report.Edges)),
+		},
+	}
+
+	for i, node := range report.Nodes {
+		document.Graph.Nodes[i] = graphMLNode{
+			ID:   node.Name,
+			Data: []graphMLData{{Key: "missing", Value: fmt.Sprint(node.Missing)}},
+		}
+	}
+
+	for i, edge := range report.Edges {
+		document.Graph.Edges[i] = graphMLEdge{
+			Source: edge.From,
+			Target: edge.To,
+			Data: []graphMLData{
+				{Key: "kind", Value: edge.Kind},
+				{Key: "broken", Value: fmt.Sprint(edge.Broken)},
+			},
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// collectSoftObjectPaths descends into decoded tag values and appends every soft object path found
+func collectSoftObjectPaths(value interface{}, result []*FSoftObjectPath) []*FSoftObjectPath {
+	switch v := value.(type) {
+	case *FSoftObjectPath:
+		return append(result, v)
+	case []*FPropertyTag:
+		for _, property := range v {
+			if property != nil {
+				result = collectSoftObjectPaths(property.Tag, result)
+			}
+		}
+	case *StructType:
+		return collectSoftObjectPaths(v.Value, result)
+	case *ArrayStructProperty:
+		return collectSoftObjectPaths(v.Properties, result)
+	case []interface{}:
+		for _, element := range v {
+			result = collectSoftObjectPaths(element, result)
+		}
+	case []*MapPropertyEntry:
+		for _, entry := range v {
+			if entry != nil {
+				result = collectSoftObjectPaths(entry.Key, result)
+				result = collectSoftObjectPaths(entry.Value, result)
+			}
+		}
+	}
+
+	return result
+}
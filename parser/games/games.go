@@ -0,0 +1,85 @@
+package games
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Vilsol/ue4pak/parser"
+)
+
+// Profile describes everything a game needs on top of the stock engine parsing
+type Profile interface {
+	Name() string
+	StructResolvers() map[string]parser.StructResolver
+	ClassResolvers() map[string]parser.ClassResolver
+	MapPropertyOverrides() map[string]*parser.MapProperty
+
+	// EngineVersion is the UE4 file version assumed for unversioned packages, 0 if unknown
+	EngineVersion() int32
+
+	// AESKeys are tried in order when the pak index is encrypted
+	AESKeys() [][]byte
+
+	// Mappings are schemas describing the struct and class layouts of the game
+	Mappings() []*parser.Schema
+}
+
+var profiles = make(map[string]Profile)
+
+// Register makes a profile selectable by its name
+func Register(profile Profile) {
+	profiles[strings.ToLower(profile.Name())] = profile
+}
+
+// Get returns the profile registered with the provided name (case-insensitive)
+func Get(name string) (Profile, bool) {
+	profile, ok := profiles[strings.ToLower(name)]
+	return profile, ok
+}
+
+// Names returns the names of all registered profiles in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+
+	for _, profile := range profiles {
+		names = append(names, profile.Name())
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NewRegistry creates a default registry extended with the profile resolvers, overrides and mappings
+func NewRegistry(profile Profile) *parser.Registry {
+	registry := parser.DefaultRegistry()
+
+	for name, resolver := range profile.StructResolvers() {
+		registry.RegisterStructResolver(name, resolver)
+	}
+
+	for name, resolver := range profile.ClassResolvers() {
+		registry.RegisterClassResolver(name, resolver)
+	}
+
+	for name, override := range profile.MapPropertyOverrides() {
+		registry.RegisterMapPropertyOverride(name, override)
+	}
+
+	for _, schema := range profile.Mappings() {
+		schema.Register(registry)
+	}
+
+	return registry
+}
+
+// Configure sets the profile engine version and AES keys on the parser
+func Configure(p *parser.PakParser, profile Profile) {
+	if version := profile.EngineVersion(); version != 0 {
+		p.SetEngineVersion(version)
+	}
+
+	for _, key := range profile.AESKeys() {
+		p.AddAESKey(key)
+	}
+}
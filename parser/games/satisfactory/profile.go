@@ -0,0 +1,47 @@
+package satisfactory
+
+import (
+	"context"
+
+	"github.com/Vilsol/ue4pak/parser"
+)
+
+type Profile struct{}
+
+func (Profile) Name() string {
+	return "Satisfactory"
+}
+
+func (Profile) StructResolvers() map[string]parser.StructResolver {
+	return map[string]parser.StructResolver{
+		"InventoryItem": func(ctx context.Context, parser *parser.PakParser, property *parser.StructProperty, size int32, uAsset *parser.FPackageFileSummary) interface{} {
+			return ReadFInventoryItem(parser)
+		},
+	}
+}
+
+func (Profile) ClassResolvers() map[string]parser.ClassResolver {
+	return nil
+}
+
+func (Profile) MapPropertyOverrides() map[string]*parser.MapProperty {
+	return map[string]*parser.MapProperty{
+		"ChildrenAndRoads_34_758C9E0D4F09DAF4BBAD309358952A0A": {
+			KeyType:   "IntVector2D",
+			ValueType: "MAMTree_RoadPoints",
+		},
+	}
+}
+
+// Satisfactory is built on UE 4.22, which saves packages as VER_UE4_FIX_WIDE_STRING_CRC
+func (Profile) EngineVersion() int32 {
+	return 517
+}
+
+func (Profile) AESKeys() [][]byte {
+	return nil
+}
+
+func (Profile) Mappings() []*parser.Schema {
+	return nil
+}
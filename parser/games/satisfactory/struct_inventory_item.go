@@ -3,8 +3,8 @@ package satisfactory
 import "github.com/Vilsol/ue4pak/parser"
 
 type FInventoryItem struct {
-	ItemClass int32 `json:"is_valid"`
-	ItemState int32 `json:"is_valid"`
+	ItemClass int32 `json:"item_class"`
+	ItemState int32 `json:"item_state"`
 }
 
 func ReadFInventoryItem(parser *parser.PakParser) *FInventoryItem {
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+)
+
+const (
+	gltfFloat         = 5126
+	gltfUnsignedByte  = 5121
+	gltfUnsignedShort = 5123
+	gltfUnsignedInt   = 5125
+
+	gltfArrayBuffer        = 34962
+	gltfElementArrayBuffer = 34963
+
+	gltfTriangles = 4
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/specification/2.0
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes"`
+	Materials   []gltfMaterial   `json:"materials,omitempty"`
+	Skins       []gltfSkin       `json:"skins,omitempty"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfScene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type gltfNode struct {
+	Name        string    `json:"name,omitempty"`
+	Mesh        *int      `json:"mesh,omitempty"`
+	Skin        *int      `json:"skin,omitempty"`
+	Children    []int     `json:"children,omitempty"`
+	Translation []float32 `json:"translation,omitempty"`
+	Rotation    []float32 `json:"rotation,omitempty"`
+	Scale       []float32 `json:"scale,omitempty"`
+}
+
+type gltfSkin struct {
+	InverseBindMatrices int   `json:"inverseBindMatrices"`
+	Joints              []int `json:"joints"`
+	Skeleton            int   `json:"skeleton"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name,omitempty"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    int            `json:"indices"`
+	Material   *int           `json:"material,omitempty"`
+	Mode       int            `json:"mode"`
+}
+
+type gltfMaterial struct {
+	Name string `json:"name,omitempty"`
+}
+
+type gltfAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Normalized    bool      `json:"normalized,omitempty"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float32 `json:"min,omitempty"`
+	Max           []float32 `json:"max,omitempty"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target,omitempty"`
+}
+
+type gltfBuffer struct {
+	ByteLength int `json:"byteLength"`
+}
+
+// gltfBuilder collects the document and the binary buffer of a glTF binary file
+type gltfBuilder struct {
+	document gltfDocument
+	buffer   bytes.Buffer
+}
+
+func newGLTFBuilder() *gltfBuilder {
+	return &gltfBuilder{
+		document: gltfDocument{
+			Asset: gltfAsset{
+				Version:   "2.0",
+				Generator: "ue4pak",
+			},
+			Scenes: []gltfScene{{Nodes: []int{}}},
+		},
+	}
+}
+
+// addAccessor appends the data to the binary buffer and returns the index of the accessor reading it
+func (builder *gltfBuilder) addAccessor(data interface{}, componentType int, accessorType string, count int, target int) int {
+	for builder.buffer.Len()%4 != 0 {
+		builder.buffer.WriteByte(0)
+	}
+
+	offset := builder.buffer.Len()
+
+	if err := binary.Write(&builder.buffer, binary.LittleEndian, data); err != nil {
+		panic(err)
+	}
+
+	builder.document.BufferViews = append(builder.document.BufferViews, gltfBufferView{
+		ByteOffset: offset,
+		ByteLength: builder.buffer.Len() - offset,
+		Target:     target,
+	})
+
+	builder.document.Accessors = append(builder.document.Accessors, gltfAccessor{
+		BufferView:    len(builder.document.BufferViews) - 1,
+		ComponentType: componentType,
+		Count:         count,
+		Type:          accessorType,
+	})
+
+	return len(builder.document.Accessors) - 1
+}
+
+// addVec3Accessor adds a float VEC3 accessor including its bounds
+func (builder *gltfBuilder) addVec3Accessor(data [][3]float32, target int) int {
+	min := []float32{math.MaxFloat32, math.MaxFloat32, math.MaxFloat32}
+	max := []float32{-math.MaxFloat32, -math.MaxFloat32, -math.MaxFloat32}
+
+	for _, value := range data {
+		for i := range value {
+			min[i] = float32(math.Min(float64(min[i]), float64(value[i])))
+			max[i] = float32(math.Max(float64(max[i]), float64(value[i])))
+		}
+	}
+
+	index := builder.addAccessor(data, gltfFloat, "VEC3", len(data), target)
+
+	if len(data) > 0 {
+		builder.document.Accessors[index].Min = min
+		builder.document.Accessors[index].Max = max
+	}
+
+	return index
+}
+
+// WriteGLB writes the document and its buffer as a glTF binary file
+func (builder *gltfBuilder) WriteGLB(w io.Writer) error {
+	for builder.buffer.Len()%4 != 0 {
+		builder.buffer.WriteByte(0)
+	}
+
+	builder.document.Buffers = []gltfBuffer{{ByteLength: builder.buffer.Len()}}
+
+	document, err := json.Marshal(builder.document)
+	if err != nil {
+		return err
+	}
+
+	for len(document)%4 != 0 {
+		document = append(document, ' ')
+	}
+
+	header := &bytes.Buffer{}
+	header.WriteString("glTF")
+	writeLittleEndian(header, uint32(2), uint32(12+8+len(document)+8+builder.buffer.Len()))
+	writeLittleEndian(header, uint32(len(document)), []byte("JSON"))
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(document); err != nil {
+		return err
+	}
+
+	header.Reset()
+	writeLittleEndian(header, uint32(builder.buffer.Len()), []byte("BIN\x00"))
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+
+	_, err = w.Write(builder.buffer.Bytes())
+	return err
+}
+
+// gltfMatrix is a column major 4x4 matrix
+type gltfMatrix [16]float64
+
+func newTRSMatrix(translation [3]float32, rotation [4]float32, scale [3]float32) gltfMatrix {
+	x, y, z, w := float64(rotation[0]), float64(rotation[1]), float64(rotation[2]), float64(rotation[3])
+	sx, sy, sz := float64(scale[0]), float64(scale[1]), float64(scale[2])
+
+	return gltfMatrix{
+		(1 - 2*(y*y+z*z)) * sx, 2 * (x*y + z*w) * sx, 2 * (x*z - y*w) * sx, 0,
+		2 * (x*y - z*w) * sy, (1 - 2*(x*x+z*z)) * sy, 2 * (y*z + x*w) * sy, 0,
+		2 * (x*z + y*w) * sz, 2 * (y*z - x*w) * sz, (1 - 2*(x*x+y*y)) * sz, 0,
+		float64(translation[0]), float64(translation[1]), float64(translation[2]), 1,
+	}
+}
+
+func (m gltfMatrix) multiply(other gltfMatrix) gltfMatrix {
+	var result gltfMatrix
+
+	for column := 0; column < 4; column++ {
+		for row := 0; row < 4; row++ {
+			for k := 0; k < 4; k++ {
+				result[column*4+row] += m[k*4+row] * other[column*4+k]
+			}
+		}
+	}
+
+	return result
+}
+
+// inverse inverts an affine matrix
+func (m gltfMatrix) inverse() gltfMatrix {
+	a, b, c := m[0], m[4], m[8]
+	d, e, f := m[1], m[5], m[9]
+	g, h, i := m[2], m[6], m[10]
+
+	determinant := a*(e*i-f*h) - b*(d*i-f*g) + c*(d*h-e*g)
+	if determinant == 0 {
+		return gltfMatrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+	}
+
+	var result gltfMatrix
+	result[0] = (e*i - f*h) / determinant
+	result[4] = -(b*i - c*h) / determinant
+	result[8] = (b*f - c*e) / determinant
+	result[1] = -(d*i - f*g) / determinant
+	result[5] = (a*i - c*g) / determinant
+	result[9] = -(a*f - c*d) / determinant
+	result[2] = (d*h - e*g) / determinant
+	result[6] = -(a*h - b*g) / determinant
+	result[10] = (a*e - b*d) / determinant
+
+	for row := 0; row < 3; row++ {
+		result[12+row] = -(result[row]*m[12] + result[4+row]*m[13] + result[8+row]*m[14])
+	}
+
+	result[15] = 1
+
+	return result
+}
@@ -2,8 +2,9 @@ package parser
 
 import (
 	"compress/zlib"
+	"crypto/cipher"
 	"fmt"
-	"github.com/spf13/viper"
+	"strings"
 )
 
 type PakParser struct {
@@ -11,6 +12,31 @@ type PakParser struct {
 	tracker    *readTracker
 	preload    []byte
 	baseReader PakReader
+	position   int64
+
+	registry  *Registry
+	noPreload bool
+
+	// engineVersion is used as the UE4 file version of unversioned packages
+	engineVersion int32
+	aesKeys       [][]byte
+	cipher        cipher.Block
+
+	// fileStart is the position the file being read starts at, the origin of raw data offsets
+	fileStart int64
+
+	// bulkDataSource is attached to the bulk data of the package being read
+	bulkDataSource BulkDataSource
+
+	// exportProperties are the tagged properties of the export being read
+	exportProperties []*FPropertyTag
+
+	// culture is the culture FText is localized to, localization holding its strings once the pak is read
+	culture      string
+	localization *FTextLocalizationResource
+
+	// stringTables resolve the FText referencing string table entries, keyed by table id
+	stringTables map[string]*UStringTable
 }
 
 type readTracker struct {
@@ -26,10 +52,77 @@ func (tracker *readTracker) Increment(n int32) {
 	}
 }
 
-func NewParser(reader PakReader) *PakParser {
+type ParserOptions struct {
+	// Registry resolves structs and classes, a copy of the built-in registry is used if nil
+	Registry *Registry
+
+	// NoPreload reads data on demand instead of buffering it (slower, but guaranteed to read)
+	NoPreload bool
+}
+
+// NewParser creates a parser with the provided options, nil options use the defaults
+func NewParser(reader PakReader, options *ParserOptions) *PakParser {
+	if options == nil {
+		options = &ParserOptions{}
+	}
+
+	registry := options.Registry
+	if registry == nil {
+		registry = DefaultRegistry()
+	}
+
 	return &PakParser{
-		reader: reader,
+		reader:    reader,
+		registry:  registry,
+		noPreload: options.NoPreload,
+	}
+}
+
+func (parser *PakParser) Registry() *Registry {
+	return parser.registry
+}
+
+// SetEngineVersion sets the UE4 file version assumed for unversioned packages
+func (parser *PakParser) SetEngineVersion(version int32) {
+	parser.engineVersion = version
+}
+
+// AddAESKey adds a key that will be tried when decrypting an encrypted pak index
+func (parser *PakParser) AddAESKey(key []byte) {
+	parser.aesKeys = append(parser.aesKeys, key)
+}
+
+// SetCulture sets the culture that FText is localized to when processing a pak, empty to not localize.
+// Only the localization stored in the processed pak is used, see SetLocalization for localizations spread over several paks.
+func (parser *PakParser) SetCulture(culture string) {
+	parser.culture = culture
+}
+
+// SetLocalization sets the strings FText is localized with
+func (parser *PakParser) SetLocalization(localization *FTextLocalizationResource) {
+	parser.localization = localization
+}
+
+// SetStringTables sets the string tables FText referencing string table entries are resolved with, keyed by table id.
+// The string tables of a processed pak are added to it before its exports are read, nil to not resolve string tables.
+func (parser *PakParser) SetStringTables(stringTables map[string]*UStringTable) {
+	parser.stringTables = stringTables
+}
+
+// ExportProperty returns the tagged property of the export being read, nil if it has none with the name
+func (parser *PakParser) ExportProperty(name string) *FPropertyTag {
+	return findProperty(parser.exportProperties, name)
+}
+
+// findProperty returns the property with the name, nil if there is none
+func findProperty(properties []*FPropertyTag, name string) *FPropertyTag {
+	for _, property := range properties {
+		if strings.Trim(property.Name, "\x00") == name {
+			return property
+		}
 	}
+
+	return nil
 }
 
 func (parser *PakParser) TrackRead() *readTracker {
@@ -48,11 +141,18 @@ func (parser *PakParser) UnTrackRead() {
 
 func (parser *PakParser) Seek(offset int64, whence int) (ret int64, err error) {
 	parser.preload = nil
-	return parser.reader.Seek(offset, whence)
+	ret, err = parser.reader.Seek(offset, whence)
+	parser.position = ret
+	return ret, err
+}
+
+// Tell returns the offset of the next byte that will be returned by Read
+func (parser *PakParser) Tell() int64 {
+	return parser.position
 }
 
 func (parser *PakParser) Preload(n int32) {
-	if viper.GetBool("NoPreload") {
+	if parser.noPreload {
 		return
 	}
 
@@ -100,6 +200,8 @@ func (parser *PakParser) Read(n int32) []byte {
 		parser.tracker.Increment(n)
 	}
 
+	parser.position += int64(n)
+
 	return buffer
 }
 
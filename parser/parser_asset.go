@@ -2,38 +2,85 @@ package parser
 
 import (
 	"context"
+	"encoding/binary"
 	"github.com/rs/zerolog/log"
+	"sort"
 	"strings"
 )
 
-func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFileSummary {
-	// Skip UE4 pak header
-	// TODO Find out what's in the pak header
-	headerSize := int64(pak.Footer.HeaderSize())
+const (
+	// fileVersionAddedSoftObjectPath is VER_UE4_ADDED_SOFT_OBJECT_PATH, since which soft package references are names
+	fileVersionAddedSoftObjectPath = 514
 
-	parser.Seek(headerSize+int64(record.FileOffset), 0)
+	// fileVersionAddedLocalizationId is VER_UE4_ADDED_PACKAGE_SUMMARY_LOCALIZATION_ID
+	fileVersionAddedLocalizationId = 516
 
-	if record.CompressionMethod == 1 {
-		// TODO
-		parser.Read(23)
+	// packageFlagFilterEditorOnly is PKG_FilterEditorOnly, set on cooked packages
+	packageFlagFilterEditorOnly = 0x80000000
+)
 
+func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFileSummary {
+	parser.Seek(record.DataOffset(pak, parser), 0)
+	parser.fileStart = parser.Tell()
+
+	if record.CompressionMethod == 1 {
 		parser.StartCompression(record.CompressionMethod)
+
+		// Restore the base reader even if reading the package panics, so the rest of the pak can still be read
+		defer parser.StopCompression()
 	}
 
 	parser.Preload(int32(record.UncompressedSize - 1))
 
+	// Offsets of the summary are relative to the start of the package
+	tracker := parser.TrackRead()
+	defer parser.UnTrackRead()
+
+	// Skipped data is kept, as tables like the thumbnail table point back into it
+	var skipped []byte
+	var skippedOffset int32
+
+	// skipTo skips to the offset, returning false if it was already read past
+	skipTo := func(offset int32) bool {
+		skip := int64(offset) - int64(tracker.bytesRead)
+
+		if skip < 0 {
+			log.Warn().Msgf("Package table at %d overlaps the previous table, ending at %d", offset, tracker.bytesRead)
+			return false
+		}
+
+		// Only the gap directly before the table is kept
+		skipped = nil
+		skippedOffset = tracker.bytesRead
+
+		if skip > 0 {
+			skipped = parser.Read(int32(skip))
+		}
+
+		return true
+	}
+
 	tag := parser.ReadInt32()
 	legacyFileVersion := parser.ReadInt32()
-	legacyUE3Version := parser.ReadInt32()
+
+	legacyUE3Version := int32(0)
+	if legacyFileVersion != -4 {
+		legacyUE3Version = parser.ReadInt32()
+	}
+
 	fileVersionUE4 := parser.ReadInt32()
 	fileVersionLicenseeUE4 := parser.ReadInt32()
 
-	// TODO custom_version_container: Vec<FCustomVersion>
-	parser.Read(4)
+	unversioned := fileVersionUE4 == 0
 
-	if pak.Footer.Version >= 9 && record.CompressionMethod == 0 {
-		// TODO Unknown bytes
-		parser.Read(3)
+	if unversioned && parser.engineVersion != 0 {
+		// Unversioned package, assume the version of the engine it was cooked with
+		fileVersionUE4 = parser.engineVersion
+	}
+
+	var customVersions []*FCustomVersion
+	if legacyFileVersion <= -2 {
+		customVersions = parser.ReadFCustomVersionContainer(legacyFileVersion)
 	}
 
 	totalHeaderSize := parser.ReadInt32()
@@ -41,6 +88,12 @@ func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFi
 	packageFlags := parser.ReadUint32()
 	nameCount := parser.ReadUint32()
 	nameOffset := parser.ReadInt32()
+
+	localizationId := ""
+	if fileVersionUE4 >= fileVersionAddedLocalizationId && packageFlags&packageFlagFilterEditorOnly == 0 {
+		localizationId = strings.Trim(parser.ReadString(), "\x00")
+	}
+
 	gatherableTextDataCount := parser.ReadInt32()
 	gatherableTextDataOffset := parser.ReadInt32()
 	exportCount := parser.ReadUint32()
@@ -78,8 +131,13 @@ func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFi
 		additionalPackagesToCook[i] = parser.ReadString()
 	}
 
+	if legacyFileVersion > -7 {
+		// Texture allocations were removed and are always empty
+		parser.ReadInt32()
+	}
+
 	assetRegistryDataOffset := parser.ReadInt32()
-	bulkDataStartOffset := parser.ReadInt32()
+	bulkDataStartOffset := parser.ReadInt64()
 	worldTileInfoDataOffset := parser.ReadInt32()
 	chunkCount := parser.ReadUint32()
 
@@ -88,9 +146,6 @@ func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFi
 		chunkIds[i] = parser.ReadInt32()
 	}
 
-	// TODO unknown bytes
-	parser.Read(4)
-
 	preloadDependencyCount := parser.ReadInt32()
 	preloadDependencyOffset := parser.ReadInt32()
 
@@ -103,6 +158,18 @@ func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFi
 		}
 	}
 
+	// Only editor packages store their localizable texts, between the names and the imports
+	var gatherableTextData []*FGatherableTextData
+	if gatherableTextDataCount > 0 && gatherableTextDataOffset > 0 && skipTo(gatherableTextDataOffset) {
+		readTable(gatherableTextDataOffset, func() {
+			gatherableTextData = parser.readGatherableTextData(gatherableTextDataCount)
+		})
+	}
+
+	if importOffset > 0 {
+		skipTo(importOffset)
+	}
+
 	imports := make([]*FObjectImport, importCount)
 	for i := uint32(0); i < importCount; i++ {
 		imports[i] = &FObjectImport{
@@ -132,10 +199,10 @@ func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFi
 			NotAlwaysLoadedForEditorGame: parser.ReadInt32() != 0,
 			IsAsset:                      parser.ReadInt32() != 0,
 			FirstExportDependency:        parser.ReadInt32(),
-			SerializationBeforeSerializationDependencies: parser.ReadInt32() != 0,
-			CreateBeforeSerializationDependencies:        parser.ReadInt32() != 0,
-			SerializationBeforeCreateDependencies:        parser.ReadInt32() != 0,
-			CreateBeforeCreateDependencies:               parser.ReadInt32() != 0,
+			SerializationBeforeSerializationDependencies: parser.ReadInt32(),
+			CreateBeforeSerializationDependencies:        parser.ReadInt32(),
+			SerializationBeforeCreateDependencies:        parser.ReadInt32(),
+			CreateBeforeCreateDependencies:               parser.ReadInt32(),
 		}
 	}
 
@@ -143,25 +210,19 @@ func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFi
 		objectImport.OuterPackage = parser.ReadFPackageIndexInt(objectImport.OuterIndex, imports, exports)
 	}
 
-	// fmt.Println("UASSET LEFTOVERS:", len(fileData[offset:]))
-	// fmt.Println(utils.HexDump(fileData[offset:]))
-
-	// TODO Bunch of unknown bytes at the end
-
-	if record.CompressionMethod == 1 {
-		parser.StopCompression()
-	}
-
-	return &FPackageFileSummary{
+	summary := &FPackageFileSummary{
 		Tag:                         tag,
 		LegacyFileVersion:           legacyFileVersion,
 		LegacyUE3Version:            legacyUE3Version,
 		FileVersionUE4:              fileVersionUE4,
 		FileVersionLicenseeUE4:      fileVersionLicenseeUE4,
+		Unversioned:                 unversioned,
+		CustomVersions:              customVersions,
 		TotalHeaderSize:             totalHeaderSize,
 		FolderName:                  folderName,
 		PackageFlags:                packageFlags,
 		NameOffset:                  nameOffset,
+		LocalizationId:              localizationId,
 		GatherableTextDataCount:     gatherableTextDataCount,
 		GatherableTextDataOffset:    gatherableTextDataOffset,
 		ExportOffset:                exportOffset,
@@ -188,20 +249,143 @@ func (record *FPakEntry) ReadUAsset(pak *PakFile, parser *PakParser) *FPackageFi
 		Names:                       names,
 		Imports:                     imports,
 		Exports:                     exports,
+		GatherableTextData:          gatherableTextData,
+	}
+
+	// Tables past the export map are read in the order they were saved in, skipping whatever lies between them
+	tables := []struct {
+		offset int32
+		read   func()
+	}{
+		{dependsOffset, func() { parser.readDependsMap(summary) }},
+		{stringAssetReferencesOffset, func() { parser.readSoftPackageReferences(summary, stringAssetReferencesCount) }},
+		{searchableNamesOffset, func() { parser.readSearchableNames(summary) }},
+		{thumbnailTableOffset, func() { parser.readThumbnails(summary, skipped, skippedOffset) }},
+		{assetRegistryDataOffset, func() { parser.readAssetRegistryData(summary) }},
+		{worldTileInfoDataOffset, func() { summary.WorldTileInfo = parser.ReadFWorldTileInfo() }},
+		{preloadDependencyOffset, func() { parser.readPreloadDependencies(summary, preloadDependencyCount) }},
+		{totalHeaderSize, nil},
+	}
+
+	sort.SliceStable(tables, func(i, j int) bool {
+		return tables[i].offset < tables[j].offset
+	})
+
+	for i, table := range tables {
+		// Tables that were not saved have no offset, or the offset of the table after them
+		if table.read == nil || table.offset <= 0 || (i+1 < len(tables) && tables[i+1].offset == table.offset) {
+			continue
+		}
+
+		if skipTo(table.offset) {
+			readTable(table.offset, table.read)
+		}
+	}
+
+	return summary
+}
+
+// readTable reads a package table, only abandoning the table if it can not be read, as the next table is skipped to
+func readTable(offset int32, read func()) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Warn().Msgf("Failed reading package table at %d: %v", offset, err)
+		}
+	}()
+
+	read()
+}
+
+// readDependsMap reads the objects each export depends on
+func (parser *PakParser) readDependsMap(summary *FPackageFileSummary) {
+	summary.DependsMap = make([][]*FPackageIndex, len(summary.Exports))
+
+	for i := range summary.DependsMap {
+		count := parser.ReadInt32()
+
+		if count < 0 {
+			log.Warn().Msgf("Invalid depends map of export %d with %d dependencies", i, count)
+			return
+		}
+
+		summary.DependsMap[i] = make([]*FPackageIndex, count)
+
+		for j := range summary.DependsMap[i] {
+			summary.DependsMap[i][j] = parser.ReadFPackageIndex(summary.Imports, summary.Exports)
+		}
+	}
+}
+
+// readSoftPackageReferences reads the names of the packages referenced through soft object paths
+func (parser *PakParser) readSoftPackageReferences(summary *FPackageFileSummary, count int32) {
+	if count < 0 {
+		log.Warn().Msgf("Invalid soft package reference count: %d", count)
+		return
+	}
+
+	summary.SoftPackageReferences = make([]string, count)
+
+	for i := range summary.SoftPackageReferences {
+		if summary.FileVersionUE4 >= fileVersionAddedSoftObjectPath {
+			summary.SoftPackageReferences[i] = strings.Trim(parser.ReadFName(summary.Names), "\x00")
+		} else {
+			summary.SoftPackageReferences[i] = strings.Trim(parser.ReadString(), "\x00")
+		}
+	}
+}
+
+// readPreloadDependencies reads the preload dependencies and splits them between the exports
+func (parser *PakParser) readPreloadDependencies(summary *FPackageFileSummary, count int32) {
+	// Uncooked packages save a count of -1, as they have no preload dependencies
+	if count < 0 {
+		return
+	}
+
+	summary.PreloadDependencies = make([]*FPackageIndex, count)
+
+	for i := range summary.PreloadDependencies {
+		summary.PreloadDependencies[i] = parser.ReadFPackageIndex(summary.Imports, summary.Exports)
+	}
+
+	for _, export := range summary.Exports {
+		if export.FirstExportDependency < 0 {
+			continue
+		}
+
+		next := export.FirstExportDependency
+		slice := func(count int32) []*FPackageIndex {
+			start := next
+			next += count
+
+			if count <= 0 || next > int32(len(summary.PreloadDependencies)) {
+				return nil
+			}
+
+			return summary.PreloadDependencies[start:next]
+		}
+
+		export.SerializationBeforeSerialization = slice(export.SerializationBeforeSerializationDependencies)
+		export.CreateBeforeSerialization = slice(export.CreateBeforeSerializationDependencies)
+		export.SerializationBeforeCreate = slice(export.SerializationBeforeCreateDependencies)
+		export.CreateBeforeCreate = slice(export.CreateBeforeCreateDependencies)
 	}
 }
 
 func (record *FPakEntry) ReadUExp(ctx context.Context, pak *PakFile, parser *PakParser, uAsset *FPackageFileSummary) []PakExportSet {
-	// Skip UE4 pak header
-	// TODO Find out what's in the pak header
-	headerSize := int64(pak.Footer.HeaderSize())
+	dataOffset := record.DataOffset(pak, parser)
+	parser.fileStart = dataOffset
 
 	exports := make([]PakExportSet, len(uAsset.Exports))
 
+	parser.bulkDataSource = newPakBulkDataSource(pak, parser, record)
+	defer func() {
+		parser.bulkDataSource = nil
+	}()
+
 	// spew.Dump(uAsset.Names)
 
 	for i, export := range uAsset.Exports {
-		offset := headerSize + record.FileOffset + (export.SerialOffset - int64(uAsset.TotalHeaderSize))
+		offset := dataOffset + (export.SerialOffset - int64(uAsset.TotalHeaderSize))
 		log.Ctx(ctx).Debug().Msgf("Reading export [%x]: %#v", offset, export.TemplateIndex.Reference)
 		parser.Seek(offset, 0)
 
@@ -210,11 +394,6 @@ func (record *FPakEntry) ReadUExp(ctx context.Context, pak *PakFile, parser *Pak
 
 		tracker := parser.TrackRead()
 
-		if pak.Footer.Version >= 9 {
-			// TODO Unknown bytes
-			parser.Read(3)
-		}
-
 		properties := parser.ReadFPropertyTagLoop(ctx, uAsset)
 
 		parser.preload = nil
@@ -225,19 +404,33 @@ func (record *FPakEntry) ReadUExp(ctx context.Context, pak *PakFile, parser *Pak
 		parser.UnTrackRead()
 
 		var data interface{}
+		var raw *RawData
+		unresolved := false
 
 		if parser.preload != nil {
 			preloadSize := len(parser.preload)
 			if preloadSize > 4 {
 				var parsed bool
+				parser.exportProperties = properties
 				data, parsed = parser.ReadClass(ctx, export, int32(preloadSize), uAsset)
+				parser.exportProperties = nil
 
 				if !parsed {
+					unresolved = true
+
 					if className := export.TemplateIndex.ClassName(); className != nil {
 						// fmt.Println(utils.HexDump(parser.preload))
 						log.Ctx(ctx).Warn().Msgf("Unknown export class type (%s)[%d]: %s", strings.Trim(export.ObjectName, "\x00"), preloadSize, strings.Trim(*className, "\x00"))
 					}
 				}
+
+				if rawData, ok := data.(*RawData); ok {
+					raw = rawData
+					data = nil
+				} else if remaining := len(parser.preload); remaining > 0 {
+					// Keep whatever the class resolver did not consume
+					raw = parser.ReadRawData(int32(remaining))
+				}
 			}
 		}
 
@@ -246,6 +439,8 @@ func (record *FPakEntry) ReadUExp(ctx context.Context, pak *PakFile, parser *Pak
 			Data: &ExportData{
 				Properties: properties,
 				Data:       data,
+				Raw:        raw,
+				Unresolved: unresolved,
 			},
 		}
 	}
@@ -306,12 +501,18 @@ func (parser *PakParser) ReadFPropertyTag(ctx context.Context, uAsset *FPackageF
 	}
 
 	var tag interface{}
+	var raw *RawData
 
 	if readData && size > 0 {
 		parser.Preload(size)
 		tracker := parser.TrackRead()
 		tag = parser.ReadTag(ctx, size, uAsset, propertyType, tagData, &name, depth)
 
+		if rawTag, ok := tag.(*RawData); ok {
+			raw = rawTag
+			tag = nil
+		}
+
 		if tracker.bytesRead != size {
 			log.Ctx(ctx).Warn().Msgf("%sProperty not read correctly %s (%s)[%#v]: %d read out of %d",
 				d(depth),
@@ -324,7 +525,7 @@ func (parser *PakParser) ReadFPropertyTag(ctx context.Context, uAsset *FPackageF
 			if tracker.bytesRead > size {
 				panic("More bytes read than available!")
 			} else {
-				parser.Read(size - tracker.bytesRead)
+				raw = parser.ReadRawData(size - tracker.bytesRead)
 			}
 		}
 
@@ -339,6 +540,7 @@ func (parser *PakParser) ReadFPropertyTag(ctx context.Context, uAsset *FPackageF
 		ArrayIndex:   arrayIndex,
 		PropertyGuid: propertyGuid,
 		Tag:          tag,
+		Raw:          raw,
 	}
 }
 
@@ -353,9 +555,18 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 		valueCount := parser.ReadInt32()
 
 		var innerTagData *FPropertyTag
+		var elementTagData interface{}
 
 		if arrayTypes == "StructProperty" {
 			innerTagData = parser.ReadFPropertyTag(ctx, uAsset, false, depth+1)
+			elementTagData = innerTagData.TagData
+
+			if structData, ok := innerTagData.TagData.(*StructProperty); ok && valueCount > 0 {
+				if !parser.IsNativeStructSize(strings.Trim(structData.Type, "\x00"), innerTagData.Size, valueCount) {
+					// Elements were serialized as tagged properties
+					elementTagData = nil
+				}
+			}
 		}
 
 		values := make([]interface{}, valueCount)
@@ -371,7 +582,7 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 				log.Ctx(ctx).Trace().Msgf("%sReading Array StructProperty: %s", d(depth), strings.Trim(innerTagData.TagData.(*StructProperty).Type, "\x00"))
 				values[i] = &ArrayStructProperty{
 					InnerTagData: innerTagData,
-					Properties:   parser.ReadTag(ctx, -1, uAsset, arrayTypes, innerTagData.TagData, nil, depth+1),
+					Properties:   parser.ReadTag(ctx, -1, uAsset, arrayTypes, elementTagData, nil, depth+1),
 				}
 				break
 			case "ObjectProperty":
@@ -405,7 +616,7 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 				values[i] = parser.ReadUint64()
 				break
 			case "TextProperty":
-				values[i] = parser.ReadFText()
+				values[i] = parser.ReadFText(uAsset)
 				break
 			case "StrProperty":
 				values[i] = parser.ReadString()
@@ -426,7 +637,7 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 		if valueCount > 0 && arrayTypes == "StructProperty" && values[0].(*ArrayStructProperty).Properties == nil {
 			if size > 0 {
 				// Struct data was not processed
-				parser.Read(innerTagData.Size)
+				innerTagData.Raw = parser.ReadRawData(innerTagData.Size)
 			}
 		}
 
@@ -441,6 +652,14 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 				result, success := parser.ReadStruct(ctx, structData, size, uAsset, depth)
 
 				if success {
+					if result == nil {
+						return nil
+					}
+
+					if raw, ok := result.(*RawData); ok {
+						return raw
+					}
+
 					return &StructType{
 						Type:  structData.Type,
 						Value: result,
@@ -473,7 +692,7 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 		tag = parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports)
 		break
 	case "TextProperty":
-		tag = parser.ReadFText()
+		tag = parser.ReadFText(uAsset)
 		break
 	case "BoolProperty":
 		// No extra data
@@ -529,7 +748,7 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 		var keyData interface{}
 		var valueData interface{}
 
-		realTagData, ok := mapPropertyTypeOverrides[strings.Trim(*name, "\x00")]
+		realTagData, ok := parser.registry.MapPropertyOverride(strings.Trim(*name, "\x00"))
 
 		if ok {
 			if strings.Trim(keyType, "\x00") != "StructProperty" {
@@ -550,27 +769,41 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 		}
 
 		if strings.Trim(keyType, "\x00") == "StructProperty" && keyData == nil {
-			parser.Read(size)
+			tag = parser.ReadRawData(size)
 			log.Ctx(ctx).Warn().Msgf("%sSkipping MapProperty [%s] %s -> %s", d(depth), strings.Trim(*name, "\x00"), strings.Trim(keyType, "\x00"), strings.Trim(valueType, "\x00"))
 			break
 		}
 
 		log.Ctx(ctx).Trace().Msgf("%sReading MapProperty [%d]: %s -> %s", d(depth), size, strings.Trim(keyType, "\x00"), strings.Trim(valueType, "\x00"))
 
+		rawOffset := parser.Tell() - parser.fileStart
 		numKeysToRemove := parser.ReadUint32()
 
 		if numKeysToRemove != 0 {
-			// TODO Read MapProperty where numKeysToRemove != 0
-			parser.Read(size - 4)
+			// Maps with keys to remove are kept raw, including the remove key count
+			raw := make([]byte, 4)
+			binary.LittleEndian.PutUint32(raw, numKeysToRemove)
+
+			tag = &RawData{
+				Offset: rawOffset,
+				Data:   append(raw, parser.Read(size-4)...),
+			}
+
 			log.Ctx(ctx).Warn().Msgf("%sSkipping MapProperty [%s] Remove Key Count: %d", d(depth), strings.Trim(*name, "\x00"), numKeysToRemove)
 			break
 		}
 
 		num := parser.ReadInt32()
 
+		// Struct keys have no declared size
+		keySize := int32(8)
+		if keyData != nil {
+			keySize = -1
+		}
+
 		results := make([]*MapPropertyEntry, num)
 		for i := int32(0); i < num; i++ {
-			key := parser.ReadTag(ctx, 8, uAsset, keyType, keyData, nil, depth+1)
+			key := parser.ReadTag(ctx, keySize, uAsset, keyType, keyData, nil, depth+1)
 
 			if key == nil {
 				parser.Read(size - 8)
@@ -590,7 +823,9 @@ func (parser *PakParser) ReadTag(ctx context.Context, size int32, uAsset *FPacka
 		break
 	default:
 		log.Ctx(ctx).Debug().Msgf("%sUnread Tag Type: %s", d(depth), strings.Trim(propertyType, "\x00"))
-		parser.Read(size)
+		if size > 0 {
+			tag = parser.ReadRawData(size)
+		}
 		break
 	}
 
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AssetRegistryObject is an object of the package with the tags the asset registry indexes it by
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/AssetRegistry/Private/PackageReader.cpp
+type AssetRegistryObject struct {
+	ObjectPath      string            `json:"object_path"`
+	ObjectClassName string            `json:"object_class_name"`
+	Tags            map[string]string `json:"tags"`
+}
+
+// readAssetRegistryData reads the asset registry data block saved in the package header
+func (parser *PakParser) readAssetRegistryData(summary *FPackageFileSummary) {
+	count := parser.ReadInt32()
+	if count < 0 {
+		log.Warn().Msgf("Invalid asset registry object count: %d", count)
+		return
+	}
+
+	summary.AssetRegistryData = make([]*AssetRegistryObject, count)
+
+	for i := range summary.AssetRegistryData {
+		object := &AssetRegistryObject{
+			ObjectPath:      strings.Trim(parser.ReadString(), "\x00"),
+			ObjectClassName: strings.Trim(parser.ReadString(), "\x00"),
+		}
+
+		tagCount := parser.ReadInt32()
+		object.Tags = make(map[string]string)
+
+		for j := int32(0); j < tagCount; j++ {
+			key := strings.Trim(parser.ReadString(), "\x00")
+			object.Tags[key] = strings.Trim(parser.ReadString(), "\x00")
+		}
+
+		summary.AssetRegistryData[i] = object
+	}
+}
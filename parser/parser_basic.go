@@ -42,6 +42,10 @@ func (parser *PakParser) ReadFloat32() float32 {
 	return value
 }
 
+func (parser *PakParser) ReadFloat64() float64 {
+	return math.Float64frombits(parser.ReadUint64())
+}
+
 func (parser *PakParser) ReadInt32() int32 {
 	return utils.Int32(parser.Read(4))
 }
@@ -61,3 +65,10 @@ func (parser *PakParser) ReadUint32() uint32 {
 func (parser *PakParser) ReadUint64() uint64 {
 	return binary.LittleEndian.Uint64(parser.Read(8))
 }
+
+func (parser *PakParser) ReadRawData(n int32) *RawData {
+	return &RawData{
+		Offset: parser.Tell() - parser.fileStart,
+		Data:   parser.Read(n),
+	}
+}
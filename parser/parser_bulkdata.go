@@ -0,0 +1,248 @@
+package parser
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/CoreUObject/Public/Serialization/BulkData.h#L297
+type FByteBulkData struct {
+	Header *FByteBulkDataHeader
+	Data   []byte
+
+	source  BulkDataSource
+	offset  int64
+	payload []byte
+
+	// err is set once loading the payload failed, so it is not attempted again
+	err error
+}
+
+const (
+	BulkDataPayloadAtEndOfFile      = 0x0001
+	BulkDataSerializeCompressedZLIB = 0x0002
+	BulkDataUnused                  = 0x0020
+	BulkDataForceInlinePayload      = 0x0040
+	BulkDataPayloadInSeparateFile   = 0x0100
+	BulkDataOptionalPayload         = 0x0800
+	BulkDataNoOffsetFixUp           = 0x10000
+)
+
+type FByteBulkDataHeader struct {
+	BulkDataFlags uint32
+	ElementCount  int32
+	SizeOnDisk    int32
+	OffsetInFile  int64
+}
+
+// BulkDataSource provides the files stored next to a package, such as the .uexp, .ubulk and .uptnl files
+type BulkDataSource interface {
+	// ReadPackageFile returns the contents of the package file with the extension, nil if it does not exist
+	ReadPackageFile(extension string) []byte
+}
+
+// pakBulkDataSource reads the package files from a pak, caching every file it has read
+type pakBulkDataSource struct {
+	pak         *PakFile
+	parser      *PakParser
+	packageName string
+	files       map[string][]byte
+}
+
+func newPakBulkDataSource(pak *PakFile, parser *PakParser, record *FPakEntry) *pakBulkDataSource {
+	trimmed := strings.Trim(record.FileName, "\x00")
+
+	return &pakBulkDataSource{
+		pak:         pak,
+		parser:      parser,
+		packageName: strings.TrimSuffix(trimmed, ".uexp"),
+		files:       make(map[string][]byte),
+	}
+}
+
+func (source *pakBulkDataSource) ReadPackageFile(extension string) []byte {
+	if data, ok := source.files[extension]; ok {
+		return data
+	}
+
+	var data []byte
+
+	if record := source.pak.FindRecord(source.packageName + extension); record != nil {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Warn().Msgf("Failed reading %s: %v", source.packageName+extension, r)
+				}
+			}()
+
+			data = record.ReadData(source.pak, source.parser)
+		}()
+	}
+
+	source.files[extension] = data
+
+	return data
+}
+
+// ReadFByteBulkData reads the bulk data header and any inline payload.
+// Payloads stored elsewhere are only read once Load is called.
+func (parser *PakParser) ReadFByteBulkData(uAsset *FPackageFileSummary) *FByteBulkData {
+	header := parser.ReadFByteBulkDataHeader()
+
+	bulkData := &FByteBulkData{
+		Header: header,
+		source: parser.bulkDataSource,
+		offset: header.OffsetInFile,
+	}
+
+	if header.BulkDataFlags&BulkDataPayloadAtEndOfFile == 0 {
+		bulkData.payload = parser.Read(header.SizeOnDisk)
+		return bulkData
+	}
+
+	// Offsets of payloads at the end of the package are counted from the start of the .uasset,
+	// and relative to the bulk data of the package unless they were already fixed up
+	if header.BulkDataFlags&(BulkDataPayloadInSeparateFile|BulkDataOptionalPayload) == 0 {
+		if header.BulkDataFlags&BulkDataNoOffsetFixUp == 0 {
+			bulkData.offset += uAsset.BulkDataStartOffset
+		}
+
+		bulkData.offset -= int64(uAsset.TotalHeaderSize)
+	}
+
+	return bulkData
+}
+
+func (parser *PakParser) ReadFByteBulkDataHeader() *FByteBulkDataHeader {
+	return &FByteBulkDataHeader{
+		BulkDataFlags: parser.ReadUint32(),
+		ElementCount:  parser.ReadInt32(),
+		SizeOnDisk:    parser.ReadInt32(),
+		OffsetInFile:  parser.ReadInt64(),
+	}
+}
+
+// SetSource sets where payloads that are not stored inline are read from
+func (bulkData *FByteBulkData) SetSource(source BulkDataSource) {
+	bulkData.source = source
+}
+
+// Load reads and decompresses the payload, returning nil if the file containing it is not available or the payload is invalid
+func (bulkData *FByteBulkData) Load() []byte {
+	if bulkData.Data != nil || bulkData.err != nil {
+		return bulkData.Data
+	}
+
+	flags := bulkData.Header.BulkDataFlags
+
+	if flags&BulkDataUnused != 0 || bulkData.Header.ElementCount == 0 {
+		return nil
+	}
+
+	payload := bulkData.payload
+
+	if payload == nil {
+		if bulkData.source == nil {
+			return nil
+		}
+
+		extension := ".uexp"
+		if flags&BulkDataOptionalPayload != 0 {
+			extension = ".uptnl"
+		} else if flags&BulkDataPayloadInSeparateFile != 0 {
+			extension = ".ubulk"
+		}
+
+		file := bulkData.source.ReadPackageFile(extension)
+
+		if file == nil {
+			return nil
+		}
+
+		end := bulkData.offset + int64(bulkData.Header.SizeOnDisk)
+
+		if bulkData.offset < 0 || end > int64(len(file)) {
+			bulkData.err = fmt.Errorf("bulk data [%x-%x] is outside of %s file of size %x", bulkData.offset, end, extension, len(file))
+			log.Warn().Err(bulkData.err).Msg("Failed loading bulk data")
+			return nil
+		}
+
+		payload = file[bulkData.offset:end]
+	}
+
+	if flags&BulkDataSerializeCompressedZLIB != 0 {
+		decompressed, err := decompressBulkData(payload)
+		if err != nil {
+			bulkData.err = err
+			log.Warn().Err(err).Msg("Failed decompressing bulk data")
+			return nil
+		}
+
+		payload = decompressed
+	}
+
+	bulkData.Data = payload
+	bulkData.payload = nil
+
+	return bulkData.Data
+}
+
+const packageFileTag = 0x9E2A83C1
+
+// decompressBulkData decompresses data written by FArchive::SerializeCompressed
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Private/Serialization/Archive.cpp#L733
+func decompressBulkData(data []byte) ([]byte, error) {
+	if len(data) < 32 {
+		return nil, errors.New("compressed bulk data is too small")
+	}
+
+	if tag := binary.LittleEndian.Uint64(data); tag != packageFileTag {
+		return nil, fmt.Errorf("invalid compressed bulk data tag: %x", tag)
+	}
+
+	chunkSize := int64(binary.LittleEndian.Uint64(data[8:]))
+	uncompressedSize := int64(binary.LittleEndian.Uint64(data[24:]))
+
+	if chunkSize <= 0 || uncompressedSize < 0 {
+		return nil, fmt.Errorf("invalid compressed bulk data sizes: %d, %d", chunkSize, uncompressedSize)
+	}
+
+	chunkCount := (uncompressedSize + chunkSize - 1) / chunkSize
+	offset := 32 + chunkCount*16
+
+	if offset > int64(len(data)) {
+		return nil, errors.New("compressed bulk data is truncated")
+	}
+
+	result := make([]byte, 0, uncompressedSize)
+
+	for i := int64(0); i < chunkCount; i++ {
+		compressedSize := int64(binary.LittleEndian.Uint64(data[32+i*16:]))
+
+		if compressedSize < 0 || offset+compressedSize > int64(len(data)) {
+			return nil, errors.New("compressed bulk data is truncated")
+		}
+
+		reader, err := zlib.NewReader(bytes.NewReader(data[offset : offset+compressedSize]))
+		if err != nil {
+			return nil, err
+		}
+
+		decompressed, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, decompressed...)
+		offset += compressedSize
+	}
+
+	return result, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"math"
 	"strings"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (parser *PakParser) ReadFGenerationInfo() *FGenerationInfo {
@@ -13,6 +15,52 @@ func (parser *PakParser) ReadFGenerationInfo() *FGenerationInfo {
 	}
 }
 
+// ReadFCustomVersionContainer reads the custom versions in the format of the legacy file version
+func (parser *PakParser) ReadFCustomVersionContainer(legacyFileVersion int32) []*FCustomVersion {
+	count := parser.ReadInt32()
+	if count < 0 {
+		log.Warn().Msgf("Invalid custom version count: %d", count)
+		return nil
+	}
+
+	versions := make([]*FCustomVersion, count)
+
+	for i := range versions {
+		switch {
+		case legacyFileVersion == -2:
+			// Enum based versions only had a tag
+			versions[i] = &FCustomVersion{
+				Key:     &FGuid{D: parser.ReadUint32()},
+				Version: parser.ReadInt32(),
+			}
+		case legacyFileVersion >= -5:
+			versions[i] = &FCustomVersion{
+				Key:          parser.ReadFGuid(),
+				Version:      parser.ReadInt32(),
+				FriendlyName: strings.Trim(parser.ReadString(), "\x00"),
+			}
+		default:
+			versions[i] = &FCustomVersion{
+				Key:     parser.ReadFGuid(),
+				Version: parser.ReadInt32(),
+			}
+		}
+	}
+
+	return versions
+}
+
+// CustomVersion returns the version of the custom version with the key, false if the package was saved without it
+func (m *FPackageFileSummary) CustomVersion(key FGuid) (int32, bool) {
+	for _, version := range m.CustomVersions {
+		if version.Key != nil && *version.Key == key {
+			return version.Version, true
+		}
+	}
+
+	return 0, false
+}
+
 func (parser *PakParser) ReadFEngineVersion() *FEngineVersion {
 	return &FEngineVersion{
 		Major:      parser.ReadUint16(),
@@ -77,7 +125,7 @@ func (parser *PakParser) ReadFPackageIndexInt(index int32, imports []*FObjectImp
 	return nil
 }
 
-func (parser *PakParser) ReadFText() *FText {
+func (parser *PakParser) ReadFText(uAsset *FPackageFileSummary) *FText {
 	flags := parser.ReadUint32()
 	historyType := int8(parser.Read(1)[0])
 
@@ -96,10 +144,20 @@ func (parser *PakParser) ReadFText() *FText {
 		return &text
 	}
 
-	if flags == 0 && historyType == 11 {
-		// TODO Unknown
-		parser.Read(8)
-		text.SourceString = parser.ReadString()
+	// StringTableEntry history, referencing a key of a StringTable
+	if historyType == 11 {
+		text.TableId = strings.Trim(parser.ReadFName(uAsset.Names), "\x00")
+		text.Key = parser.ReadString()
+
+		if table, ok := parser.stringTables[text.TableId]; ok {
+			text.SourceString = table.Entries[text.Key]
+
+			// String table entries are localized by the namespace of the table
+			if parser.localization != nil {
+				text.LocalizedString, _ = parser.localization.Localize(table.Namespace, text.Key)
+			}
+		}
+
 		return &text
 	}
 
@@ -111,6 +169,10 @@ func (parser *PakParser) ReadFText() *FText {
 	text.Key = parser.ReadString()
 	text.SourceString = parser.ReadString()
 
+	if parser.localization != nil {
+		text.LocalizedString, _ = parser.localization.Localize(text.Namespace, text.Key)
+	}
+
 	return &text
 }
 
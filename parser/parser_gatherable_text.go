@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Internationalization/GatherableTextData.h
+type FGatherableTextData struct {
+	NamespaceName      string                    `json:"namespace_name"`
+	SourceData         *FTextSourceData          `json:"source_data"`
+	SourceSiteContexts []*FTextSourceSiteContext `json:"source_site_contexts"`
+}
+
+type FTextSourceData struct {
+	SourceString         string              `json:"source_string"`
+	SourceStringMetaData *FLocMetadataObject `json:"source_string_meta_data"`
+}
+
+type FTextSourceSiteContext struct {
+	KeyName         string              `json:"key_name"`
+	SiteDescription string              `json:"site_description"`
+	IsEditorOnly    bool                `json:"is_editor_only"`
+	IsOptional      bool                `json:"is_optional"`
+	InfoMetaData    *FLocMetadataObject `json:"info_meta_data"`
+	KeyMetaData     *FLocMetadataObject `json:"key_meta_data"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Internationalization/LocMetadataValue.h
+const (
+	LocMetadataTypeNone = iota
+	LocMetadataTypeBoolean
+	LocMetadataTypeString
+	LocMetadataTypeArray
+	LocMetadataTypeObject
+)
+
+// FLocMetadataObject holds bool, string, []interface{} and *FLocMetadataObject values
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Internationalization/LocMetadataObject.h
+type FLocMetadataObject struct {
+	Values map[string]interface{} `json:"values"`
+}
+
+// SearchableNames are the names an object of the package can be found by
+type SearchableNames struct {
+	Object *FPackageIndex `json:"object"`
+	Names  []string       `json:"names"`
+}
+
+func (parser *PakParser) ReadFGatherableTextData() *FGatherableTextData {
+	data := &FGatherableTextData{
+		NamespaceName: strings.Trim(parser.ReadString(), "\x00"),
+		SourceData: &FTextSourceData{
+			SourceString:         strings.Trim(parser.ReadString(), "\x00"),
+			SourceStringMetaData: parser.ReadFLocMetadataObject(),
+		},
+	}
+
+	count := parser.ReadInt32()
+	if count < 0 {
+		panic(fmt.Sprintf("invalid source site context count of %s: %d", data.NamespaceName, count))
+	}
+
+	data.SourceSiteContexts = make([]*FTextSourceSiteContext, count)
+
+	for i := range data.SourceSiteContexts {
+		data.SourceSiteContexts[i] = &FTextSourceSiteContext{
+			KeyName:         strings.Trim(parser.ReadString(), "\x00"),
+			SiteDescription: strings.Trim(parser.ReadString(), "\x00"),
+			IsEditorOnly:    parser.ReadInt32() != 0,
+			IsOptional:      parser.ReadInt32() != 0,
+			InfoMetaData:    parser.ReadFLocMetadataObject(),
+			KeyMetaData:     parser.ReadFLocMetadataObject(),
+		}
+	}
+
+	return data
+}
+
+func (parser *PakParser) ReadFLocMetadataObject() *FLocMetadataObject {
+	count := parser.ReadInt32()
+
+	object := &FLocMetadataObject{
+		Values: make(map[string]interface{}),
+	}
+
+	for i := int32(0); i < count; i++ {
+		key := strings.Trim(parser.ReadString(), "\x00")
+		object.Values[key] = parser.readLocMetadataValue()
+	}
+
+	return object
+}
+
+func (parser *PakParser) readLocMetadataValue() interface{} {
+	valueType := parser.ReadInt32()
+
+	switch valueType {
+	case LocMetadataTypeBoolean:
+		return parser.ReadInt32() != 0
+	case LocMetadataTypeString:
+		return strings.Trim(parser.ReadString(), "\x00")
+	case LocMetadataTypeArray:
+		count := parser.ReadInt32()
+		if count < 0 {
+			panic(fmt.Sprintf("invalid localization metadata array count: %d", count))
+		}
+
+		values := make([]interface{}, count)
+		for i := range values {
+			values[i] = parser.readLocMetadataValue()
+		}
+		return values
+	case LocMetadataTypeObject:
+		return parser.ReadFLocMetadataObject()
+	}
+
+	// The size of unknown values is unknown, so nothing after them can be read
+	panic(fmt.Sprintf("unknown localization metadata type: %d", valueType))
+}
+
+// readGatherableTextData reads the localizable texts of the package
+func (parser *PakParser) readGatherableTextData(count int32) []*FGatherableTextData {
+	if count < 0 {
+		log.Warn().Msgf("Invalid gatherable text data count: %d", count)
+		return nil
+	}
+
+	result := make([]*FGatherableTextData, count)
+
+	for i := range result {
+		result[i] = parser.ReadFGatherableTextData()
+	}
+
+	return result
+}
+
+// readSearchableNames reads the map of objects to the names they can be searched by
+func (parser *PakParser) readSearchableNames(summary *FPackageFileSummary) {
+	count := parser.ReadInt32()
+	if count < 0 {
+		log.Warn().Msgf("Invalid searchable names count: %d", count)
+		return
+	}
+
+	summary.SearchableNames = make([]*SearchableNames, count)
+
+	for i := range summary.SearchableNames {
+		object := parser.ReadFPackageIndex(summary.Imports, summary.Exports)
+
+		nameCount := parser.ReadInt32()
+		if nameCount < 0 {
+			log.Warn().Msgf("Invalid searchable name count: %d", nameCount)
+			summary.SearchableNames = summary.SearchableNames[:i]
+			return
+		}
+
+		entry := &SearchableNames{
+			Object: object,
+			Names:  make([]string, nameCount),
+		}
+
+		for j := range entry.Names {
+			entry.Names[j] = strings.Trim(parser.ReadFName(summary.Names), "\x00")
+		}
+
+		summary.SearchableNames[i] = entry
+	}
+}
+
+// SourceStrings returns every localizable string of the package, keyed by namespace and key
+func (m *FPackageFileSummary) SourceStrings() map[string]string {
+	result := make(map[string]string)
+
+	for _, data := range m.GatherableTextData {
+		for _, context := range data.SourceSiteContexts {
+			result[data.NamespaceName+"/"+context.KeyName] = data.SourceData.SourceString
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,261 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	LocResVersionLegacy                   = 0
+	LocResVersionCompact                  = 1
+	LocResVersionOptimizedCRC32           = 2
+	LocResVersionOptimizedCityHash64UTF16 = 3
+
+	LocMetaVersionInitial               = 0
+	LocMetaVersionAddedCompiledCultures = 1
+)
+
+var locResMagic = []byte{0x0E, 0x14, 0x74, 0x75, 0x67, 0x4A, 0x03, 0xFC, 0x4A, 0x15, 0x90, 0x9D, 0xC3, 0x37, 0x7F, 0x1B}
+var locMetaMagic = []byte{0x4F, 0xEE, 0x4C, 0xA1, 0x68, 0x48, 0x55, 0x83, 0x6C, 0x4C, 0x46, 0xBD, 0x70, 0xDA, 0x50, 0x7C}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Private/Internationalization/TextLocalizationResource.cpp
+type FTextLocalizationResource struct {
+	Version uint8 `json:"version"`
+
+	// Entries are the localized strings by namespace, then by key
+	Entries map[string]map[string]string `json:"entries"`
+}
+
+type FTextLocalizationMetaDataResource struct {
+	Version          uint8    `json:"version"`
+	NativeCulture    string   `json:"native_culture"`
+	NativeLocRes     string   `json:"native_loc_res"`
+	CompiledCultures []string `json:"compiled_cultures"`
+}
+
+// ReadLocRes reads a .locres file, positioned at its start
+func (parser *PakParser) ReadLocRes() *FTextLocalizationResource {
+	start := parser.Tell()
+
+	resource := &FTextLocalizationResource{
+		Version: LocResVersionLegacy,
+		Entries: make(map[string]map[string]string),
+	}
+
+	// Legacy files have no magic and start with the namespace count
+	if magic := parser.Read(16); bytes.Equal(magic, locResMagic) {
+		resource.Version = parser.Read(1)[0]
+	} else {
+		parser.Seek(start, 0)
+	}
+
+	var localizedStrings []string
+
+	if resource.Version >= LocResVersionCompact {
+		arrayOffset := parser.ReadInt64()
+
+		if arrayOffset != -1 {
+			current := parser.Tell()
+			parser.Seek(start+arrayOffset, 0)
+
+			count := parser.ReadInt32()
+			localizedStrings = make([]string, count)
+
+			for i := range localizedStrings {
+				localizedStrings[i] = parser.ReadString()
+
+				// Reference count
+				if resource.Version >= LocResVersionOptimizedCRC32 {
+					parser.ReadInt32()
+				}
+			}
+
+			parser.Seek(current, 0)
+		}
+	}
+
+	// Entry count
+	if resource.Version >= LocResVersionOptimizedCRC32 {
+		parser.ReadUint32()
+	}
+
+	namespaceCount := parser.ReadUint32()
+	for i := uint32(0); i < namespaceCount; i++ {
+		namespace := parser.readLocResKey(resource.Version)
+
+		entries, ok := resource.Entries[namespace]
+		if !ok {
+			entries = make(map[string]string)
+			resource.Entries[namespace] = entries
+		}
+
+		keyCount := parser.ReadUint32()
+		for j := uint32(0); j < keyCount; j++ {
+			key := parser.readLocResKey(resource.Version)
+
+			// Source string hash
+			parser.ReadUint32()
+
+			if resource.Version >= LocResVersionCompact {
+				index := parser.ReadInt32()
+
+				if index >= 0 && int(index) < len(localizedStrings) {
+					entries[key] = localizedStrings[index]
+				}
+			} else {
+				entries[key] = parser.ReadString()
+			}
+		}
+	}
+
+	return resource
+}
+
+// readLocResKey reads a namespace or key, hashed by the optimized versions
+func (parser *PakParser) readLocResKey(version uint8) string {
+	if version >= LocResVersionOptimizedCRC32 {
+		parser.ReadUint32()
+	}
+
+	return parser.ReadString()
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Private/Internationalization/TextLocalizationResource.cpp
+func (parser *PakParser) ReadLocMeta() *FTextLocalizationMetaDataResource {
+	if magic := parser.Read(16); !bytes.Equal(magic, locMetaMagic) {
+		panic("invalid locmeta magic")
+	}
+
+	resource := &FTextLocalizationMetaDataResource{
+		Version:       parser.Read(1)[0],
+		NativeCulture: parser.ReadString(),
+		NativeLocRes:  parser.ReadString(),
+	}
+
+	if resource.Version >= LocMetaVersionAddedCompiledCultures {
+		count := parser.ReadInt32()
+		resource.CompiledCultures = make([]string, count)
+
+		for i := range resource.CompiledCultures {
+			resource.CompiledCultures[i] = parser.ReadString()
+		}
+	}
+
+	return resource
+}
+
+// Localize returns the localized string of the namespace and key, false if the resource has none
+func (resource *FTextLocalizationResource) Localize(namespace string, key string) (string, bool) {
+	if entries, ok := resource.Entries[namespace]; ok {
+		value, ok := entries[key]
+		return value, ok
+	}
+
+	return "", false
+}
+
+// Merge adds the entries of the other resource, replacing existing translations
+func (resource *FTextLocalizationResource) Merge(other *FTextLocalizationResource) {
+	for namespace, entries := range other.Entries {
+		if _, ok := resource.Entries[namespace]; !ok {
+			resource.Entries[namespace] = make(map[string]string)
+		}
+
+		for key, value := range entries {
+			resource.Entries[namespace][key] = value
+		}
+	}
+}
+
+// ReadLocalization merges every .locres file of the culture, stored as Localization/<Target>/<Culture>/<Target>.locres
+func (pak *PakFile) ReadLocalization(ctx context.Context, parser *PakParser, culture string) *FTextLocalizationResource {
+	resource := &FTextLocalizationResource{
+		Entries: make(map[string]map[string]string),
+	}
+
+	for _, record := range pak.Index.Records {
+		name := strings.Trim(record.FileName, "\x00")
+
+		if record.IsEncrypted && (path.Ext(name) == ".locmeta" || path.Ext(name) == ".locres") {
+			log.Ctx(ctx).Warn().Msgf("Skipping encrypted localization: %s", name)
+			continue
+		}
+
+		if path.Ext(name) == ".locmeta" {
+			pak.readLocalizationFile(ctx, parser, record, func(fileParser *PakParser) {
+				meta := fileParser.ReadLocMeta()
+				log.Ctx(ctx).Debug().Msgf("Localization %s has cultures: %s", name, strings.Join(meta.CompiledCultures, ", "))
+			})
+			continue
+		}
+
+		if path.Ext(name) != ".locres" || path.Base(path.Dir(name)) != culture {
+			continue
+		}
+
+		log.Ctx(ctx).Info().Msgf("Reading Localization: %s", name)
+
+		pak.readLocalizationFile(ctx, parser, record, func(fileParser *PakParser) {
+			resource.Merge(fileParser.ReadLocRes())
+		})
+	}
+
+	return resource
+}
+
+// readLocalizationFile reads the contents of the record, logging and skipping files that are invalid
+func (pak *PakFile) readLocalizationFile(ctx context.Context, parser *PakParser, record *FPakEntry, read func(*PakParser)) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Ctx(ctx).Warn().Msgf("Skipping invalid localization %s: %v", strings.Trim(record.FileName, "\x00"), r)
+		}
+	}()
+
+	read(NewParser(&PakByteReader{Bytes: record.ReadData(pak, parser)}, nil))
+}
+
+// readStringTables adds the StringTable exports of the packages to the string tables of the parser, keyed by their
+// table id, e.g. /Game/Path/Table.Table
+func (parser *PakParser) readStringTables(ctx context.Context, pak *PakFile, summaries map[string]*FPackageFileSummary) {
+	for name, summary := range summaries {
+		uexp := pak.FindRecord(name + ".uexp")
+		if uexp == nil {
+			continue
+		}
+
+		log.Ctx(ctx).Info().Msgf("Reading String Table: %s", name)
+
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Ctx(ctx).Warn().Msgf("Skipping unreadable string table %s: %v", name, r)
+				}
+			}()
+
+			for _, exportSet := range uexp.ReadUExp(ctx, pak, parser, summary) {
+				if exportSet.Data == nil {
+					continue
+				}
+
+				if table, ok := exportSet.Data.Data.(*UStringTable); ok {
+					parser.stringTables[PackageName(name)+"."+strings.Trim(exportSet.Export.ObjectName, "\x00")] = table
+				}
+			}
+		}()
+	}
+}
+
+// hasStringTable reports whether the package has a StringTable export
+func hasStringTable(summary *FPackageFileSummary) bool {
+	for _, export := range summary.Exports {
+		if exportClassName(export) == "StringTable" {
+			return true
+		}
+	}
+
+	return false
+}
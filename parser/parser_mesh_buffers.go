@@ -0,0 +1,255 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/x448/float16"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/EngineUtils.h
+type FStripDataFlags struct {
+	GlobalStripFlags uint8 `json:"global_strip_flags"`
+	ClassStripFlags  uint8 `json:"class_strip_flags"`
+}
+
+const (
+	StripEditor = 0x1
+	StripServer = 0x2
+)
+
+func (parser *PakParser) ReadFStripDataFlags() *FStripDataFlags {
+	return &FStripDataFlags{
+		GlobalStripFlags: parser.Read(1)[0],
+		ClassStripFlags:  parser.Read(1)[0],
+	}
+}
+
+func (flags *FStripDataFlags) IsEditorDataStripped() bool {
+	return flags.GlobalStripFlags&StripEditor != 0
+}
+
+func (flags *FStripDataFlags) IsDataStrippedForServer() bool {
+	return flags.GlobalStripFlags&StripServer != 0
+}
+
+func (flags *FStripDataFlags) IsClassDataStripped(flag uint8) bool {
+	return flags.ClassStripFlags&flag != 0
+}
+
+// readBulkArray reads an array serialized with TArray::BulkSerialize, checking the element size
+func (parser *PakParser) readBulkArray(elementSize int32) (int32, []byte) {
+	serializedSize := parser.ReadInt32()
+	count := parser.ReadInt32()
+
+	if count > 0 && serializedSize != elementSize {
+		panic(fmt.Sprintf("unexpected bulk array element size: %d != %d", serializedSize, elementSize))
+	}
+
+	return count, parser.Read(serializedSize * count)
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/PositionVertexBuffer.h
+type FPositionVertexBuffer struct {
+	Stride      int32     `json:"stride"`
+	NumVertices int32     `json:"num_vertices"`
+	Vertices    []FVector `json:"-"`
+}
+
+func (parser *PakParser) ReadFPositionVertexBuffer() *FPositionVertexBuffer {
+	buffer := &FPositionVertexBuffer{
+		Stride:      parser.ReadInt32(),
+		NumVertices: parser.ReadInt32(),
+	}
+
+	count, data := parser.readBulkArray(12)
+	buffer.Vertices = make([]FVector, count)
+
+	for i := range buffer.Vertices {
+		buffer.Vertices[i] = FVector{
+			X: readFloat32At(data, i*12),
+			Y: readFloat32At(data, i*12+4),
+			Z: readFloat32At(data, i*12+8),
+		}
+	}
+
+	return buffer
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/StaticMeshVertexBuffer.h
+type FStaticMeshVertexBuffer struct {
+	NumTexCoords                 int32 `json:"num_tex_coords"`
+	NumVertices                  int32 `json:"num_vertices"`
+	UseFullPrecisionUVs          bool  `json:"use_full_precision_uvs"`
+	UseHighPrecisionTangentBasis bool  `json:"use_high_precision_tangent_basis"`
+
+	// Tangents are the TangentX of each vertex
+	Tangents []FVector `json:"-"`
+
+	// Normals are the TangentZ of each vertex, W holding the sign of the binormal
+	Normals []FVector4 `json:"-"`
+
+	// UVs are indexed by texture coordinate, then by vertex
+	UVs [][]FVector2D `json:"-"`
+}
+
+func (parser *PakParser) ReadFStaticMeshVertexBuffer() *FStaticMeshVertexBuffer {
+	stripFlags := parser.ReadFStripDataFlags()
+
+	buffer := &FStaticMeshVertexBuffer{
+		NumTexCoords:                 parser.ReadInt32(),
+		NumVertices:                  parser.ReadInt32(),
+		UseFullPrecisionUVs:          parser.ReadInt32() != 0,
+		UseHighPrecisionTangentBasis: parser.ReadInt32() != 0,
+	}
+
+	if stripFlags.IsDataStrippedForServer() {
+		return buffer
+	}
+
+	tangentSize := int32(8)
+	if buffer.UseHighPrecisionTangentBasis {
+		tangentSize = 16
+	}
+
+	count, data := parser.readBulkArray(tangentSize)
+	buffer.Tangents = make([]FVector, count)
+	buffer.Normals = make([]FVector4, count)
+
+	for i := 0; i < int(count); i++ {
+		offset := i * int(tangentSize)
+
+		if buffer.UseHighPrecisionTangentBasis {
+			buffer.Tangents[i] = unpackRGBA16N(data[offset:]).vector()
+			buffer.Normals[i] = unpackRGBA16N(data[offset+8:])
+		} else {
+			buffer.Tangents[i] = unpackNormal(data[offset:]).vector()
+			buffer.Normals[i] = unpackNormal(data[offset+4:])
+		}
+	}
+
+	uvSize := int32(4)
+	if buffer.UseFullPrecisionUVs {
+		uvSize = 8
+	}
+
+	count, data = parser.readBulkArray(uvSize)
+	buffer.UVs = make([][]FVector2D, buffer.NumTexCoords)
+
+	for channel := range buffer.UVs {
+		buffer.UVs[channel] = make([]FVector2D, buffer.NumVertices)
+	}
+
+	// UVs are stored interleaved per vertex
+	for i := 0; i < int(count); i++ {
+		offset := i * int(uvSize)
+		uv := &buffer.UVs[i%int(buffer.NumTexCoords)][i/int(buffer.NumTexCoords)]
+
+		if buffer.UseFullPrecisionUVs {
+			uv.X = readFloat32At(data, offset)
+			uv.Y = readFloat32At(data, offset+4)
+		} else {
+			uv.X = float16.Frombits(binary.LittleEndian.Uint16(data[offset:])).Float32()
+			uv.Y = float16.Frombits(binary.LittleEndian.Uint16(data[offset+2:])).Float32()
+		}
+	}
+
+	return buffer
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/ColorVertexBuffer.h
+type FColorVertexBuffer struct {
+	Stride      int32 `json:"stride"`
+	NumVertices int32 `json:"num_vertices"`
+
+	// Colors are stored as BGRA
+	Colors [][4]uint8 `json:"-"`
+}
+
+func (parser *PakParser) ReadFColorVertexBuffer() *FColorVertexBuffer {
+	stripFlags := parser.ReadFStripDataFlags()
+
+	buffer := &FColorVertexBuffer{
+		Stride:      parser.ReadInt32(),
+		NumVertices: parser.ReadInt32(),
+	}
+
+	if stripFlags.IsDataStrippedForServer() || buffer.NumVertices <= 0 {
+		return buffer
+	}
+
+	count, data := parser.readBulkArray(4)
+	buffer.Colors = make([][4]uint8, count)
+
+	for i := range buffer.Colors {
+		copy(buffer.Colors[i][:], data[i*4:])
+	}
+
+	return buffer
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/RawIndexBuffer.h
+type FRawStaticIndexBuffer struct {
+	Is32Bit bool     `json:"is_32_bit"`
+	Indices []uint32 `json:"-"`
+}
+
+func (parser *PakParser) ReadFRawStaticIndexBuffer() *FRawStaticIndexBuffer {
+	buffer := &FRawStaticIndexBuffer{
+		Is32Bit: parser.ReadInt32() != 0,
+	}
+
+	_, data := parser.readBulkArray(1)
+
+	if buffer.Is32Bit {
+		buffer.Indices = make([]uint32, len(data)/4)
+
+		for i := range buffer.Indices {
+			buffer.Indices[i] = binary.LittleEndian.Uint32(data[i*4:])
+		}
+	} else {
+		buffer.Indices = make([]uint32, len(data)/2)
+
+		for i := range buffer.Indices {
+			buffer.Indices[i] = uint32(binary.LittleEndian.Uint16(data[i*2:]))
+		}
+	}
+
+	return buffer
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Math/RandomStream.h
+func (parser *PakParser) skipFWeightedRandomSampler() {
+	parser.Read(parser.ReadInt32() * 4) // Prob
+	parser.Read(parser.ReadInt32() * 4) // Alias
+	parser.ReadFloat32()                // TotalWeight
+}
+
+// unpackNormal unpacks an FPackedNormal, stored as signed bytes since 4.20
+func unpackNormal(data []byte) FVector4 {
+	return FVector4{
+		X: float32(int8(data[0])) / 127,
+		Y: float32(int8(data[1])) / 127,
+		Z: float32(int8(data[2])) / 127,
+		W: float32(int8(data[3])) / 127,
+	}
+}
+
+// unpackRGBA16N unpacks an FPackedRGBA16N
+func unpackRGBA16N(data []byte) FVector4 {
+	return FVector4{
+		X: float32(int16(binary.LittleEndian.Uint16(data))) / 32767,
+		Y: float32(int16(binary.LittleEndian.Uint16(data[2:]))) / 32767,
+		Z: float32(int16(binary.LittleEndian.Uint16(data[4:]))) / 32767,
+		W: float32(int16(binary.LittleEndian.Uint16(data[6:]))) / 32767,
+	}
+}
+
+func (vector FVector4) vector() FVector {
+	return FVector{X: vector.X, Y: vector.Y, Z: vector.Z}
+}
+
+func readFloat32At(data []byte, offset int) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(data[offset:]))
+}
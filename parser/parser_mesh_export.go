@@ -0,0 +1,381 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ueToMeters converts Unreal units (centimeters) to glTF and OBJ units (meters)
+const ueToMeters = 0.01
+
+// MeshGeometry is the vertex and index data of a single mesh LOD, in Unreal coordinates
+type MeshGeometry struct {
+	Positions []FVector
+	Normals   []FVector4
+	Tangents  []FVector
+	UVs       [][]FVector2D
+
+	// Colors are stored as BGRA
+	Colors [][4]uint8
+
+	Indices   []uint32
+	Sections  []MeshSection
+	Materials []string
+
+	// Joints and Weights are the bone influences of each vertex, Joints indexing Bones
+	Joints  [][]uint16
+	Weights [][]uint8
+	Bones   []MeshBone
+}
+
+type MeshBone struct {
+	Name        string
+	ParentIndex int32
+	Transform   *FTransform
+}
+
+type MeshSection struct {
+	MaterialIndex int32
+	FirstIndex    int32
+	NumTriangles  int32
+}
+
+// Geometry returns the geometry of the LOD at the index
+func (mesh *StaticMesh) Geometry(lodIndex int) (*MeshGeometry, error) {
+	if mesh.RenderData == nil || lodIndex < 0 || lodIndex >= len(mesh.RenderData.LODs) {
+		return nil, fmt.Errorf("mesh has no LOD %d", lodIndex)
+	}
+
+	lod := mesh.RenderData.LODs[lodIndex]
+
+	if lod.PositionVertexBuffer == nil || lod.VertexBuffer == nil || lod.IndexBuffer == nil {
+		return nil, fmt.Errorf("LOD %d has no vertex data", lodIndex)
+	}
+
+	geometry := &MeshGeometry{
+		Positions: lod.PositionVertexBuffer.Vertices,
+		Normals:   lod.VertexBuffer.Normals,
+		Tangents:  lod.VertexBuffer.Tangents,
+		UVs:       lod.VertexBuffer.UVs,
+		Indices:   lod.IndexBuffer.Indices,
+		Sections:  make([]MeshSection, len(lod.Sections)),
+		Materials: make([]string, len(mesh.StaticMaterials)),
+	}
+
+	if lod.ColorVertexBuffer != nil {
+		geometry.Colors = lod.ColorVertexBuffer.Colors
+	}
+
+	for i, section := range lod.Sections {
+		geometry.Sections[i] = MeshSection{
+			MaterialIndex: section.MaterialIndex,
+			FirstIndex:    section.FirstIndex,
+			NumTriangles:  section.NumTriangles,
+		}
+	}
+
+	for i, material := range mesh.StaticMaterials {
+		geometry.Materials[i] = material.MaterialSlotName
+	}
+
+	return geometry, nil
+}
+
+// WriteGLB writes the LOD at the index as a glTF binary file
+func (mesh *StaticMesh) WriteGLB(w io.Writer, lodIndex int) error {
+	geometry, err := mesh.Geometry(lodIndex)
+	if err != nil {
+		return err
+	}
+
+	builder := newGLTFBuilder()
+	builder.addGeometry("StaticMesh", geometry)
+
+	return builder.WriteGLB(w)
+}
+
+// WriteOBJ writes the LOD at the index as a Wavefront OBJ file
+func (mesh *StaticMesh) WriteOBJ(w io.Writer, lodIndex int) error {
+	geometry, err := mesh.Geometry(lodIndex)
+	if err != nil {
+		return err
+	}
+
+	return geometry.WriteOBJ(w)
+}
+
+// convertVector converts a left handed Z up vector to a right handed Y up vector
+func convertVector(vector FVector, scale float32) [3]float32 {
+	return [3]float32{vector.X * scale, vector.Z * scale, vector.Y * scale}
+}
+
+// convertQuat converts a rotation to the coordinate system of convertVector
+func convertQuat(quat FQuat) [4]float32 {
+	return [4]float32{-quat.X, -quat.Z, -quat.Y, quat.W}
+}
+
+func normalize(vector [3]float32) [3]float32 {
+	length := float32(math.Sqrt(float64(vector[0]*vector[0] + vector[1]*vector[1] + vector[2]*vector[2])))
+
+	if length == 0 {
+		return [3]float32{0, 0, 1}
+	}
+
+	return [3]float32{vector[0] / length, vector[1] / length, vector[2] / length}
+}
+
+// sectionIndices returns the triangles of the section, reversing their winding to match the converted coordinates
+func (geometry *MeshGeometry) sectionIndices(section MeshSection) []uint32 {
+	start := int(section.FirstIndex)
+	end := start + int(section.NumTriangles)*3
+
+	if end > len(geometry.Indices) {
+		end = len(geometry.Indices)
+	}
+
+	indices := make([]uint32, 0, end-start)
+
+	for i := start; i+2 < end; i += 3 {
+		indices = append(indices, geometry.Indices[i], geometry.Indices[i+2], geometry.Indices[i+1])
+	}
+
+	return indices
+}
+
+// addGeometry adds the geometry as a mesh attached to a root node of the scene, returning the index of the node
+func (builder *gltfBuilder) addGeometry(name string, geometry *MeshGeometry) int {
+	document := &builder.document
+	vertexCount := len(geometry.Positions)
+
+	positions := make([][3]float32, vertexCount)
+	for i, position := range geometry.Positions {
+		positions[i] = convertVector(position, ueToMeters)
+	}
+
+	attributes := map[string]int{
+		"POSITION": builder.addVec3Accessor(positions, gltfArrayBuffer),
+	}
+
+	if len(geometry.Normals) == vertexCount {
+		normals := make([][3]float32, vertexCount)
+		tangents := make([][4]float32, vertexCount)
+
+		for i, normal := range geometry.Normals {
+			normals[i] = normalize(convertVector(normal.vector(), 1))
+
+			// The handedness of the bitangent flips with the coordinate system
+			sign := float32(-1)
+			if normal.W < 0 {
+				sign = 1
+			}
+
+			tangent := normalize(convertVector(geometry.Tangents[i], 1))
+			tangents[i] = [4]float32{tangent[0], tangent[1], tangent[2], sign}
+		}
+
+		attributes["NORMAL"] = builder.addAccessor(normals, gltfFloat, "VEC3", vertexCount, gltfArrayBuffer)
+		attributes["TANGENT"] = builder.addAccessor(tangents, gltfFloat, "VEC4", vertexCount, gltfArrayBuffer)
+	}
+
+	for channel, uvs := range geometry.UVs {
+		if len(uvs) != vertexCount {
+			continue
+		}
+
+		attributes["TEXCOORD_"+strconv.Itoa(channel)] = builder.addAccessor(uvs, gltfFloat, "VEC2", vertexCount, gltfArrayBuffer)
+	}
+
+	if len(geometry.Colors) == vertexCount {
+		colors := make([][4]uint8, vertexCount)
+		for i, color := range geometry.Colors {
+			colors[i] = [4]uint8{color[2], color[1], color[0], color[3]}
+		}
+
+		index := builder.addAccessor(colors, gltfUnsignedByte, "VEC4", vertexCount, gltfArrayBuffer)
+		document.Accessors[index].Normalized = true
+		attributes["COLOR_0"] = index
+	}
+
+	if len(geometry.Joints) == vertexCount && vertexCount > 0 {
+		// Influences are written in sets of 4
+		for set := 0; set*4 < len(geometry.Joints[0]); set++ {
+			joints := make([][4]uint16, vertexCount)
+			weights := make([][4]uint8, vertexCount)
+
+			for i := range joints {
+				copy(joints[i][:], geometry.Joints[i][set*4:])
+				copy(weights[i][:], geometry.Weights[i][set*4:])
+			}
+
+			attributes["JOINTS_"+strconv.Itoa(set)] = builder.addAccessor(joints, gltfUnsignedShort, "VEC4", vertexCount, gltfArrayBuffer)
+
+			index := builder.addAccessor(weights, gltfUnsignedByte, "VEC4", vertexCount, gltfArrayBuffer)
+			document.Accessors[index].Normalized = true
+			attributes["WEIGHTS_"+strconv.Itoa(set)] = index
+		}
+	}
+
+	materialOffset := len(document.Materials)
+	for _, material := range geometry.Materials {
+		document.Materials = append(document.Materials, gltfMaterial{Name: material})
+	}
+
+	mesh := gltfMesh{
+		Name:       name,
+		Primitives: make([]gltfPrimitive, 0, len(geometry.Sections)),
+	}
+
+	for _, section := range geometry.Sections {
+		indices := geometry.sectionIndices(section)
+
+		if len(indices) == 0 {
+			continue
+		}
+
+		primitive := gltfPrimitive{
+			Attributes: attributes,
+			Indices:    builder.addAccessor(indices, gltfUnsignedInt, "SCALAR", len(indices), gltfElementArrayBuffer),
+			Mode:       gltfTriangles,
+		}
+
+		if int(section.MaterialIndex) < len(geometry.Materials) && section.MaterialIndex >= 0 {
+			material := materialOffset + int(section.MaterialIndex)
+			primitive.Material = &material
+		}
+
+		mesh.Primitives = append(mesh.Primitives, primitive)
+	}
+
+	document.Meshes = append(document.Meshes, mesh)
+	meshIndex := len(document.Meshes) - 1
+
+	document.Nodes = append(document.Nodes, gltfNode{Name: name, Mesh: &meshIndex})
+	nodeIndex := len(document.Nodes) - 1
+	document.Scenes[0].Nodes = append(document.Scenes[0].Nodes, nodeIndex)
+
+	if len(geometry.Bones) > 0 && len(geometry.Joints) == vertexCount {
+		skin := builder.addSkin(geometry.Bones)
+		document.Nodes[nodeIndex].Skin = &skin
+	}
+
+	return nodeIndex
+}
+
+// addSkin adds a node for every bone and a skin binding them, returning the index of the skin
+func (builder *gltfBuilder) addSkin(bones []MeshBone) int {
+	document := &builder.document
+	firstNode := len(document.Nodes)
+
+	joints := make([]int, len(bones))
+	globals := make([]gltfMatrix, len(bones))
+	inverseBindMatrices := make([][16]float32, len(bones))
+	skeleton := -1
+
+	for i, bone := range bones {
+		translation := convertVector(*bone.Transform.Translation, ueToMeters)
+		rotation := convertQuat(*bone.Transform.Rotation)
+		scale := convertVector(*bone.Transform.Scale3D, 1)
+
+		joints[i] = firstNode + i
+		document.Nodes = append(document.Nodes, gltfNode{
+			Name:        bone.Name,
+			Translation: translation[:],
+			Rotation:    rotation[:],
+			Scale:       scale[:],
+		})
+
+		local := newTRSMatrix(translation, rotation, scale)
+
+		// Parents always precede their children in the reference skeleton
+		if bone.ParentIndex >= 0 && int(bone.ParentIndex) < i {
+			globals[i] = globals[bone.ParentIndex].multiply(local)
+
+			parent := &document.Nodes[firstNode+int(bone.ParentIndex)]
+			parent.Children = append(parent.Children, firstNode+i)
+		} else {
+			globals[i] = local
+			document.Scenes[0].Nodes = append(document.Scenes[0].Nodes, firstNode+i)
+
+			if skeleton < 0 {
+				skeleton = firstNode + i
+			}
+		}
+
+		inverse := globals[i].inverse()
+		for j := range inverse {
+			inverseBindMatrices[i][j] = float32(inverse[j])
+		}
+	}
+
+	document.Skins = append(document.Skins, gltfSkin{
+		InverseBindMatrices: builder.addAccessor(inverseBindMatrices, gltfFloat, "MAT4", len(bones), 0),
+		Joints:              joints,
+		Skeleton:            skeleton,
+	})
+
+	return len(document.Skins) - 1
+}
+
+// WriteOBJ writes the geometry as a Wavefront OBJ file, using the first UV channel
+func (geometry *MeshGeometry) WriteOBJ(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	hasNormals := len(geometry.Normals) == len(geometry.Positions)
+	hasUVs := len(geometry.UVs) > 0 && len(geometry.UVs[0]) == len(geometry.Positions)
+
+	for _, position := range geometry.Positions {
+		converted := convertVector(position, ueToMeters)
+		fmt.Fprintf(out, "v %g %g %g\n", converted[0], converted[1], converted[2])
+	}
+
+	if hasUVs {
+		for _, uv := range geometry.UVs[0] {
+			// OBJ texture coordinates start at the bottom
+			fmt.Fprintf(out, "vt %g %g\n", uv.X, 1-uv.Y)
+		}
+	}
+
+	if hasNormals {
+		for _, normal := range geometry.Normals {
+			converted := normalize(convertVector(normal.vector(), 1))
+			fmt.Fprintf(out, "vn %g %g %g\n", converted[0], converted[1], converted[2])
+		}
+	}
+
+	for i, section := range geometry.Sections {
+		material := "Section_" + strconv.Itoa(i)
+		if int(section.MaterialIndex) < len(geometry.Materials) && section.MaterialIndex >= 0 {
+			material = geometry.Materials[section.MaterialIndex]
+		}
+
+		fmt.Fprintf(out, "g %s\nusemtl %s\n", material, material)
+
+		indices := geometry.sectionIndices(section)
+		for j := 0; j+2 < len(indices); j += 3 {
+			out.WriteString("f")
+
+			for _, index := range indices[j : j+3] {
+				// OBJ indices start at 1
+				vertex := index + 1
+
+				switch {
+				case hasUVs && hasNormals:
+					fmt.Fprintf(out, " %d/%d/%d", vertex, vertex, vertex)
+				case hasUVs:
+					fmt.Fprintf(out, " %d/%d", vertex, vertex)
+				case hasNormals:
+					fmt.Fprintf(out, " %d//%d", vertex, vertex)
+				default:
+					fmt.Fprintf(out, " %d", vertex)
+				}
+			}
+
+			out.WriteString("\n")
+		}
+	}
+
+	return out.Flush()
+}
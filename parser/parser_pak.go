@@ -1,8 +1,13 @@
 package parser
 
 import (
+	"bytes"
+	"compress/zlib"
 	"context"
+	"fmt"
 	"github.com/rs/zerolog/log"
+	"io/ioutil"
+	"strings"
 )
 
 const INDEX_NONE = int64(-1)
@@ -27,11 +32,13 @@ func (parser *PakParser) Parse(ctx context.Context) *PakFile {
 		}
 	}
 
-	// Seek and read the footer of the file
-	parser.Seek(magicOffset, 2)
+	// Seek and read the footer of the file, starting with the encrypted index flag preceding the magic
+	parser.Seek(magicOffset-1, 2)
 
 	pakFooter := &FPakInfo{}
 
+	pakFooter.EncryptedIndex = parser.Read(1)[0] != 0
+
 	pakFooter.Magic = parser.ReadUint32()
 	pakFooter.Version = parser.ReadUint32()
 	pakFooter.IndexOffset = parser.ReadUint64()
@@ -50,7 +57,12 @@ func (parser *PakParser) Parse(ctx context.Context) *PakFile {
 
 	// Seek and read the index of the file
 	parser.Seek(int64(pakFooter.IndexOffset), 0)
-	parser.Preload(int32(pakFooter.IndexSize))
+
+	if pakFooter.EncryptedIndex {
+		parser.PreloadEncrypted(int32(pakFooter.IndexSize))
+	} else {
+		parser.Preload(int32(pakFooter.IndexSize))
+	}
 
 	mountPoint := parser.ReadString()
 	recordCount := parser.ReadInt32()
@@ -211,7 +223,12 @@ func (parser *PakParser) DecodePakEntries(pakIndex *FPakIndex, pakFooter *FPakIn
 		panic("TODO") // TODO
 	} else {
 		parser.Seek(FullDirectoryIndexOffset, 0)
-		parser.Preload(int32(FullDirectoryIndexSize))
+
+		if pakFooter.EncryptedIndex {
+			parser.PreloadEncrypted(int32(FullDirectoryIndexSize))
+		} else {
+			parser.Preload(int32(FullDirectoryIndexSize))
+		}
 
 		directoryCount := parser.ReadInt32()
 		for i := int32(0); i < directoryCount; i++ {
@@ -252,14 +269,20 @@ func (parser *PakParser) DecodeLegacyPakEntries(pakIndex *FPakIndex, pakFooter *
 
 func (parser *PakParser) DecodeFPakEntry(entry *FPakEntry, version uint32) {
 	entry.FileName = parser.ReadString()
+	parser.ReadFPakEntry(entry, version)
+}
+
+// ReadFPakEntry reads an entry as serialized after its file name in the index, and in front of the data of the file
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/PakFile/Public/IPlatformFilePak.h
+func (parser *PakParser) ReadFPakEntry(entry *FPakEntry, version uint32) {
 	entry.FileOffset = parser.ReadInt64()
 	entry.FileSize = parser.ReadInt64()
 	entry.UncompressedSize = parser.ReadInt64()
 
-	if version <= 8 {
+	if version == 8 {
 		entry.CompressionMethod = uint32(parser.Read(1)[0])
 	} else {
-		entry.CompressionMethod = uint32(parser.ReadInt32())
+		entry.CompressionMethod = parser.ReadUint32()
 	}
 
 	if version <= 1 {
@@ -282,17 +305,149 @@ func (parser *PakParser) DecodeFPakEntry(entry *FPakEntry, version uint32) {
 			}
 		}
 
-		entry.IsEncrypted = parser.Read(1)[0] > 0
+		// The second flag marks deleted entries
+		entry.IsEncrypted = parser.Read(1)[0]&1 != 0
 		entry.CompressionBlockSize = parser.ReadUint32()
 	}
+}
+
+// SerializedSize returns the size of the entry in front of the data of the file
+func (record *FPakEntry) SerializedSize(version uint32) int64 {
+	// Offset, sizes and hash
+	size := int64(8 + 8 + 8 + 20)
+
+	if version == 8 {
+		size++
+	} else {
+		size += 4
+	}
+
+	if version <= 1 {
+		size += 8
+	}
+
+	if version >= 3 {
+		// Flags and compression block size
+		size += 1 + 4
+
+		if record.CompressionMethod != 0 {
+			size += 4 + int64(len(record.CompressionBlocks))*16
+		}
+	}
+
+	return size
+}
+
+// ReadHeader reads the entry stored in front of the data of the file, returning an error if it does not match the index
+func (record *FPakEntry) ReadHeader(pak *PakFile, parser *PakParser) (header *FPakEntry, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			header = nil
+			err = fmt.Errorf("failed reading entry header: %v", r)
+		}
+	}()
+
+	parser.Seek(record.FileOffset, 0)
+
+	header = &FPakEntry{}
+	parser.ReadFPakEntry(header, pak.Footer.Version)
+
+	// The offset in front of the data is not the offset of the entry, so it is not compared
+	switch {
+	case header.FileSize != record.FileSize:
+		return header, fmt.Errorf("size %d does not match the index size %d", header.FileSize, record.FileSize)
+	case header.UncompressedSize != record.UncompressedSize:
+		return header, fmt.Errorf("uncompressed size %d does not match the index uncompressed size %d", header.UncompressedSize, record.UncompressedSize)
+	case header.CompressionMethod != record.CompressionMethod:
+		return header, fmt.Errorf("compression method %d does not match the index compression method %d", header.CompressionMethod, record.CompressionMethod)
+	case header.IsEncrypted != record.IsEncrypted:
+		return header, fmt.Errorf("encryption flag %t does not match the index encryption flag %t", header.IsEncrypted, record.IsEncrypted)
+	case len(record.DataSHA1Hash) > 0 && !bytes.Equal(header.DataSHA1Hash, record.DataSHA1Hash):
+		return header, fmt.Errorf("hash %x does not match the index hash %x", header.DataSHA1Hash, record.DataSHA1Hash)
+	case len(header.CompressionBlocks) != len(record.CompressionBlocks):
+		return header, fmt.Errorf("%d compression blocks do not match the %d index compression blocks", len(header.CompressionBlocks), len(record.CompressionBlocks))
+	}
+
+	for i, block := range header.CompressionBlocks {
+		if *block != *record.CompressionBlocks[i] {
+			return header, fmt.Errorf("compression block %d does not match the index compression block", i)
+		}
+	}
+
+	return header, nil
+}
+
+// DataOffset returns the offset of the data of the file, following the entry header.
+// A header that does not match the index is reported as corruption of the index, and the header is trusted.
+func (record *FPakEntry) DataOffset(pak *PakFile, parser *PakParser) int64 {
+	if record.dataOffset != 0 {
+		return record.dataOffset
+	}
+
+	header, err := record.ReadHeader(pak, parser)
+	if err != nil {
+		log.Error().Err(err).Msgf("Pak index is corrupt: %s", strings.Trim(record.FileName, "\x00"))
+	}
 
-	if version == 4 {
-		// TODO ???
+	if header == nil {
+		header = record
 	}
 
-	if version >= 9 {
-		// TODO Unknown bytes
-		// parser.Read(3)
+	record.dataOffset = record.FileOffset + header.SerializedSize(pak.Footer.Version)
+
+	return record.dataOffset
+}
+
+// FindRecord returns the record of the file with the provided name, nil if the pak does not contain it
+func (pak *PakFile) FindRecord(name string) *FPakEntry {
+	for _, record := range pak.Index.Records {
+		if strings.Trim(record.FileName, "\x00") == name {
+			return record
+		}
+	}
+
+	return nil
+}
+
+// ReadData reads and decompresses the whole contents of the record
+func (record *FPakEntry) ReadData(pak *PakFile, parser *PakParser) []byte {
+	if record.IsEncrypted {
+		panic("reading encrypted entries is not supported")
+	}
+
+	if record.CompressionMethod == 0 {
+		parser.Seek(record.DataOffset(pak, parser), 0)
+		return parser.Read(int32(record.UncompressedSize))
+	}
+
+	if record.CompressionMethod != 1 {
+		panic(fmt.Sprintf("unknown compression method: %d", record.CompressionMethod))
+	}
+
+	// Block offsets are relative to the record since version 5
+	baseOffset := int64(0)
+	if pak.Footer.Version >= 5 {
+		baseOffset = record.FileOffset
+	}
+
+	data := make([]byte, 0, record.UncompressedSize)
+
+	for _, block := range record.CompressionBlocks {
+		parser.Seek(baseOffset+int64(block.StartOffset), 0)
+		compressed := parser.Read(int32(block.EndOffset - block.StartOffset))
+
+		reader, err := zlib.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			panic(err)
+		}
+
+		decompressed, err := ioutil.ReadAll(reader)
+		if err != nil {
+			panic(err)
+		}
+
+		data = append(data, decompressed...)
 	}
 
+	return data
 }
@@ -0,0 +1,339 @@
+package parser
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// PackageFilterEditorOnly is set on packages saved without editor only data
+	PackageFilterEditorOnly = 0x80000000
+
+	ClassStripDuplicatedVertices = 0x1
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/SkeletalMesh.cpp
+type SkeletalMesh struct {
+	ImportedBounds    *FBoxSphereBounds             `json:"imported_bounds"`
+	Materials         []*FSkeletalMaterial          `json:"materials"`
+	ReferenceSkeleton *FReferenceSkeleton           `json:"reference_skeleton"`
+	Cooked            bool                          `json:"cooked"`
+	LODs              []*FSkeletalMeshLODRenderData `json:"lods"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Engine/SkeletalMesh.h
+type FSkeletalMaterial struct {
+	MaterialInterface        *FPackageIndex      `json:"material_interface"`
+	MaterialSlotName         string              `json:"material_slot_name"`
+	ImportedMaterialSlotName string              `json:"imported_material_slot_name,omitempty"`
+	UVChannelData            *FMeshUVChannelInfo `json:"uv_channel_data"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/ReferenceSkeleton.h
+type FReferenceSkeleton struct {
+	RefBoneInfo    []*FMeshBoneInfo `json:"ref_bone_info"`
+	RefBonePose    []*FTransform    `json:"ref_bone_pose"`
+	NameToIndexMap map[string]int32 `json:"name_to_index_map"`
+}
+
+type FMeshBoneInfo struct {
+	Name        string `json:"name"`
+	ParentIndex int32  `json:"parent_index"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/SkeletalMeshLODRenderData.h
+type FSkeletalMeshLODRenderData struct {
+	Sections             []*FSkelMeshRenderSection `json:"sections"`
+	Indices              []uint32                  `json:"-"`
+	ActiveBoneIndices    []uint16                  `json:"active_bone_indices"`
+	RequiredBones        []uint16                  `json:"required_bones"`
+	PositionVertexBuffer *FPositionVertexBuffer    `json:"position_vertex_buffer"`
+	VertexBuffer         *FStaticMeshVertexBuffer  `json:"vertex_buffer"`
+	SkinWeightBuffer     *FSkinWeightVertexBuffer  `json:"skin_weight_buffer"`
+	ColorVertexBuffer    *FColorVertexBuffer       `json:"color_vertex_buffer"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/SkeletalMeshLODRenderData.h
+type FSkelMeshRenderSection struct {
+	MaterialIndex     int16    `json:"material_index"`
+	BaseIndex         int32    `json:"base_index"`
+	NumTriangles      int32    `json:"num_triangles"`
+	RecomputeTangent  bool     `json:"recompute_tangent"`
+	CastShadow        bool     `json:"cast_shadow"`
+	BaseVertexIndex   uint32   `json:"base_vertex_index"`
+	HasClothData      bool     `json:"has_cloth_data"`
+	BoneMap           []uint16 `json:"bone_map"`
+	NumVertices       int32    `json:"num_vertices"`
+	MaxBoneInfluences int32    `json:"max_bone_influences"`
+	Disabled          bool     `json:"disabled"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/SkinWeightVertexBuffer.h
+type FSkinWeightVertexBuffer struct {
+	ExtraBoneInfluences bool  `json:"extra_bone_influences"`
+	NumVertices         int32 `json:"num_vertices"`
+
+	// BoneIndices are the section local bone indices of each vertex, 4 or 8 depending on ExtraBoneInfluences
+	BoneIndices [][]uint8 `json:"-"`
+	BoneWeights [][]uint8 `json:"-"`
+}
+
+func (parser *PakParser) ReadSkeletalMesh(ctx context.Context, uAsset *FPackageFileSummary) *SkeletalMesh {
+	// UObject guid
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+
+	stripFlags := parser.ReadFStripDataFlags()
+
+	mesh := &SkeletalMesh{
+		ImportedBounds: parser.ReadFBoxSphereBounds(),
+	}
+
+	materialCount := parser.ReadInt32()
+	mesh.Materials = make([]*FSkeletalMaterial, materialCount)
+	for i := int32(0); i < materialCount; i++ {
+		mesh.Materials[i] = parser.ReadFSkeletalMaterial(uAsset)
+	}
+
+	mesh.ReferenceSkeleton = parser.ReadFReferenceSkeleton(uAsset)
+
+	if !stripFlags.IsEditorDataStripped() {
+		log.Ctx(ctx).Warn().Msg("SkeletalMesh with editor data is not supported")
+		return mesh
+	}
+
+	mesh.Cooked = parser.ReadInt32() != 0
+
+	if !mesh.Cooked {
+		log.Ctx(ctx).Warn().Msg("Uncooked SkeletalMesh")
+		return mesh
+	}
+
+	hasVertexColors := false
+	if property := parser.ExportProperty("bHasVertexColors"); property != nil {
+		hasVertexColors, _ = property.TagData.(bool)
+	}
+
+	lodCount := parser.ReadInt32()
+	mesh.LODs = make([]*FSkeletalMeshLODRenderData, lodCount)
+	for i := int32(0); i < lodCount; i++ {
+		mesh.LODs[i] = parser.ReadFSkeletalMeshLODRenderData(hasVertexColors)
+	}
+
+	return mesh
+}
+
+func (parser *PakParser) ReadFSkeletalMaterial(uAsset *FPackageFileSummary) *FSkeletalMaterial {
+	material := &FSkeletalMaterial{
+		MaterialInterface: parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports),
+		MaterialSlotName:  strings.Trim(parser.ReadFName(uAsset.Names), "\x00"),
+	}
+
+	hasImportedMaterialSlotName := uAsset.PackageFlags&PackageFilterEditorOnly == 0
+
+	// Whether the imported slot name is serialized is stored since 4.22
+	if uAsset.SavedByEngineVersion != nil && uAsset.SavedByEngineVersion.AtLeast(4, 22) {
+		hasImportedMaterialSlotName = parser.ReadInt32() != 0
+	}
+
+	if hasImportedMaterialSlotName {
+		material.ImportedMaterialSlotName = strings.Trim(parser.ReadFName(uAsset.Names), "\x00")
+	}
+
+	material.UVChannelData = parser.ReadFMeshUVChannelInfo()
+
+	return material
+}
+
+func (parser *PakParser) ReadFReferenceSkeleton(uAsset *FPackageFileSummary) *FReferenceSkeleton {
+	boneCount := parser.ReadInt32()
+
+	skeleton := &FReferenceSkeleton{
+		RefBoneInfo: make([]*FMeshBoneInfo, boneCount),
+	}
+
+	for i := int32(0); i < boneCount; i++ {
+		skeleton.RefBoneInfo[i] = &FMeshBoneInfo{
+			Name:        strings.Trim(parser.ReadFName(uAsset.Names), "\x00"),
+			ParentIndex: parser.ReadInt32(),
+		}
+
+		// Export names are editor only
+		if uAsset.PackageFlags&PackageFilterEditorOnly == 0 {
+			parser.ReadString()
+		}
+	}
+
+	poseCount := parser.ReadInt32()
+	skeleton.RefBonePose = make([]*FTransform, poseCount)
+	for i := int32(0); i < poseCount; i++ {
+		skeleton.RefBonePose[i] = parser.ReadFTransform()
+	}
+
+	nameCount := parser.ReadInt32()
+	skeleton.NameToIndexMap = make(map[string]int32, nameCount)
+	for i := int32(0); i < nameCount; i++ {
+		name := strings.Trim(parser.ReadFName(uAsset.Names), "\x00")
+		skeleton.NameToIndexMap[name] = parser.ReadInt32()
+	}
+
+	return skeleton
+}
+
+func (parser *PakParser) ReadFSkeletalMeshLODRenderData(hasVertexColors bool) *FSkeletalMeshLODRenderData {
+	stripFlags := parser.ReadFStripDataFlags()
+
+	sectionCount := parser.ReadInt32()
+
+	lod := &FSkeletalMeshLODRenderData{
+		Sections: make([]*FSkelMeshRenderSection, sectionCount),
+	}
+
+	hasClothData := false
+	for i := int32(0); i < sectionCount; i++ {
+		lod.Sections[i] = parser.ReadFSkelMeshRenderSection()
+		hasClothData = hasClothData || lod.Sections[i].HasClothData
+	}
+
+	lod.Indices = parser.readFMultisizeIndexContainer()
+	lod.ActiveBoneIndices = parser.readUint16Array()
+	lod.RequiredBones = parser.readUint16Array()
+
+	if stripFlags.IsDataStrippedForServer() || stripFlags.IsClassDataStripped(ClassStripMinLodData) {
+		return lod
+	}
+
+	lod.PositionVertexBuffer = parser.ReadFPositionVertexBuffer()
+	lod.VertexBuffer = parser.ReadFStaticMeshVertexBuffer()
+	lod.SkinWeightBuffer = parser.ReadFSkinWeightVertexBuffer()
+
+	if hasVertexColors {
+		lod.ColorVertexBuffer = parser.ReadFColorVertexBuffer()
+	}
+
+	// Adjacency index buffer
+	if !stripFlags.IsClassDataStripped(ClassStripAdjacencyData) {
+		parser.readFMultisizeIndexContainer()
+	}
+
+	if hasClothData {
+		parser.skipFSkeletalMeshVertexClothBuffer()
+	}
+
+	return lod
+}
+
+func (parser *PakParser) ReadFSkelMeshRenderSection() *FSkelMeshRenderSection {
+	stripFlags := parser.ReadFStripDataFlags()
+
+	section := &FSkelMeshRenderSection{
+		MaterialIndex:    int16(parser.ReadUint16()),
+		BaseIndex:        parser.ReadInt32(),
+		NumTriangles:     parser.ReadInt32(),
+		RecomputeTangent: parser.ReadInt32() != 0,
+		CastShadow:       parser.ReadInt32() != 0,
+		BaseVertexIndex:  parser.ReadUint32(),
+	}
+
+	// Cloth mapping data, FMeshToMeshVertData is 64 bytes
+	clothMappingCount := parser.ReadInt32()
+	parser.Read(clothMappingCount * 64)
+	section.HasClothData = clothMappingCount > 0
+
+	section.BoneMap = parser.readUint16Array()
+	section.NumVertices = parser.ReadInt32()
+	section.MaxBoneInfluences = parser.ReadInt32()
+
+	// CorrespondClothAssetIndex and the clothing asset guid and LOD index
+	parser.ReadUint16()
+	parser.ReadFGuid()
+	parser.ReadInt32()
+
+	// Duplicated vertices buffer
+	if !stripFlags.IsClassDataStripped(ClassStripDuplicatedVertices) {
+		parser.readBulkArray(4)
+		parser.readBulkArray(8)
+	}
+
+	section.Disabled = parser.ReadInt32() != 0
+
+	return section
+}
+
+func (parser *PakParser) ReadFSkinWeightVertexBuffer() *FSkinWeightVertexBuffer {
+	stripFlags := parser.ReadFStripDataFlags()
+
+	buffer := &FSkinWeightVertexBuffer{
+		ExtraBoneInfluences: parser.ReadInt32() != 0,
+		NumVertices:         parser.ReadInt32(),
+	}
+
+	if stripFlags.IsDataStrippedForServer() {
+		return buffer
+	}
+
+	influences := 4
+	if buffer.ExtraBoneInfluences {
+		influences = 8
+	}
+
+	count, data := parser.readBulkArray(int32(influences * 2))
+	buffer.BoneIndices = make([][]uint8, count)
+	buffer.BoneWeights = make([][]uint8, count)
+
+	for i := 0; i < int(count); i++ {
+		offset := i * influences * 2
+		buffer.BoneIndices[i] = data[offset : offset+influences]
+		buffer.BoneWeights[i] = data[offset+influences : offset+influences*2]
+	}
+
+	return buffer
+}
+
+// readFMultisizeIndexContainer reads 16 or 32 bit indices
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/MultiSizeIndexContainer.h
+func (parser *PakParser) readFMultisizeIndexContainer() []uint32 {
+	dataSize := parser.Read(1)[0]
+
+	count, data := parser.readBulkArray(int32(dataSize))
+	indices := make([]uint32, count)
+
+	for i := range indices {
+		if dataSize == 2 {
+			indices[i] = uint32(binary.LittleEndian.Uint16(data[i*2:]))
+		} else {
+			indices[i] = binary.LittleEndian.Uint32(data[i*4:])
+		}
+	}
+
+	return indices
+}
+
+func (parser *PakParser) readUint16Array() []uint16 {
+	count := parser.ReadInt32()
+	values := make([]uint16, count)
+
+	for i := range values {
+		values[i] = parser.ReadUint16()
+	}
+
+	return values
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/Rendering/SkeletalMeshLODRenderData.h
+func (parser *PakParser) skipFSkeletalMeshVertexClothBuffer() {
+	stripFlags := parser.ReadFStripDataFlags()
+
+	if stripFlags.IsDataStrippedForServer() {
+		return
+	}
+
+	// Mapping data and cloth index mapping
+	size := parser.ReadInt32()
+	parser.Read(size * parser.ReadInt32())
+	parser.Read(parser.ReadInt32() * 8)
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+)
+
+// Geometry returns the geometry of the LOD at the index, including its bone influences
+func (mesh *SkeletalMesh) Geometry(lodIndex int) (*MeshGeometry, error) {
+	if lodIndex < 0 || lodIndex >= len(mesh.LODs) {
+		return nil, fmt.Errorf("mesh has no LOD %d", lodIndex)
+	}
+
+	lod := mesh.LODs[lodIndex]
+
+	if lod.PositionVertexBuffer == nil || lod.VertexBuffer == nil {
+		return nil, fmt.Errorf("LOD %d has no vertex data", lodIndex)
+	}
+
+	geometry := &MeshGeometry{
+		Positions: lod.PositionVertexBuffer.Vertices,
+		Normals:   lod.VertexBuffer.Normals,
+		Tangents:  lod.VertexBuffer.Tangents,
+		UVs:       lod.VertexBuffer.UVs,
+		Indices:   lod.Indices,
+		Sections:  make([]MeshSection, 0, len(lod.Sections)),
+		Materials: make([]string, len(mesh.Materials)),
+	}
+
+	if lod.ColorVertexBuffer != nil {
+		geometry.Colors = lod.ColorVertexBuffer.Colors
+	}
+
+	for i, material := range mesh.Materials {
+		geometry.Materials[i] = material.MaterialSlotName
+	}
+
+	for _, section := range lod.Sections {
+		if section.Disabled {
+			continue
+		}
+
+		geometry.Sections = append(geometry.Sections, MeshSection{
+			MaterialIndex: int32(section.MaterialIndex),
+			FirstIndex:    section.BaseIndex,
+			NumTriangles:  section.NumTriangles,
+		})
+	}
+
+	if mesh.ReferenceSkeleton == nil || lod.SkinWeightBuffer == nil || len(lod.SkinWeightBuffer.BoneIndices) != len(geometry.Positions) {
+		return geometry, nil
+	}
+
+	geometry.Bones = make([]MeshBone, len(mesh.ReferenceSkeleton.RefBoneInfo))
+	for i, bone := range mesh.ReferenceSkeleton.RefBoneInfo {
+		geometry.Bones[i] = MeshBone{
+			Name:        bone.Name,
+			ParentIndex: bone.ParentIndex,
+			Transform:   mesh.ReferenceSkeleton.RefBonePose[i],
+		}
+	}
+
+	weights := lod.SkinWeightBuffer
+	geometry.Joints = make([][]uint16, len(geometry.Positions))
+	geometry.Weights = make([][]uint8, len(geometry.Positions))
+
+	for i := range geometry.Joints {
+		geometry.Joints[i] = make([]uint16, len(weights.BoneIndices[i]))
+		geometry.Weights[i] = weights.BoneWeights[i]
+	}
+
+	// Bone indices of the weight buffer are local to the bone map of the section
+	for _, section := range lod.Sections {
+		start := int(section.BaseVertexIndex)
+		end := start + int(section.NumVertices)
+
+		if end > len(geometry.Joints) {
+			end = len(geometry.Joints)
+		}
+
+		for i := start; i < end; i++ {
+			for j, index := range weights.BoneIndices[i] {
+				if int(index) < len(section.BoneMap) && weights.BoneWeights[i][j] > 0 {
+					geometry.Joints[i][j] = section.BoneMap[index]
+				}
+			}
+		}
+	}
+
+	return geometry, nil
+}
+
+// WriteGLB writes the LOD at the index as a glTF binary file with a skin bound to the reference skeleton
+func (mesh *SkeletalMesh) WriteGLB(w io.Writer, lodIndex int) error {
+	geometry, err := mesh.Geometry(lodIndex)
+	if err != nil {
+		return err
+	}
+
+	builder := newGLTFBuilder()
+	builder.addGeometry("SkeletalMesh", geometry)
+
+	return builder.WriteGLB(w)
+}
+
+// WriteOBJ writes the LOD at the index as a Wavefront OBJ file, without its bones
+func (mesh *SkeletalMesh) WriteOBJ(w io.Writer, lodIndex int) error {
+	geometry, err := mesh.Geometry(lodIndex)
+	if err != nil {
+		return err
+	}
+
+	return geometry.WriteOBJ(w)
+}
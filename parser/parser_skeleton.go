@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"context"
+	"strings"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/Animation/Skeleton.cpp
+type Skeleton struct {
+	ReferenceSkeleton *FReferenceSkeleton `json:"reference_skeleton"`
+	RetargetSources   []*FReferencePose   `json:"retarget_sources"`
+	Guid              *FGuid              `json:"guid"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Animation/Skeleton.h
+type FReferencePose struct {
+	Name          string         `json:"name"`
+	PoseName      string         `json:"pose_name"`
+	ReferencePose []*FTransform  `json:"reference_pose"`
+	ReferenceMesh *FPackageIndex `json:"reference_mesh,omitempty"`
+}
+
+func (parser *PakParser) ReadSkeleton(ctx context.Context, uAsset *FPackageFileSummary) *Skeleton {
+	// UObject guid
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+
+	skeleton := &Skeleton{
+		ReferenceSkeleton: parser.ReadFReferenceSkeleton(uAsset),
+	}
+
+	retargetSourceCount := parser.ReadInt32()
+	skeleton.RetargetSources = make([]*FReferencePose, retargetSourceCount)
+	for i := int32(0); i < retargetSourceCount; i++ {
+		skeleton.RetargetSources[i] = parser.ReadFReferencePose(uAsset)
+	}
+
+	skeleton.Guid = parser.ReadFGuid()
+
+	// The smart name container and marker names that follow are kept as raw data
+
+	return skeleton
+}
+
+func (parser *PakParser) ReadFReferencePose(uAsset *FPackageFileSummary) *FReferencePose {
+	pose := &FReferencePose{
+		Name:     strings.Trim(parser.ReadFName(uAsset.Names), "\x00"),
+		PoseName: strings.Trim(parser.ReadFName(uAsset.Names), "\x00"),
+	}
+
+	count := parser.ReadInt32()
+	pose.ReferencePose = make([]*FTransform, count)
+	for i := int32(0); i < count; i++ {
+		pose.ReferencePose[i] = parser.ReadFTransform()
+	}
+
+	// The reference mesh is editor only
+	if uAsset.PackageFlags&PackageFilterEditorOnly == 0 {
+		pose.ReferenceMesh = parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports)
+	}
+
+	return pose
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/SoundWave.cpp#L276
+type SoundWave struct {
+	Cooked               bool                        `json:"cooked"`
+	Streaming            bool                        `json:"streaming"`
+	SampleRate           int32                       `json:"sample_rate"`
+	NumChannels          int32                       `json:"num_channels"`
+	CompressedFormatData map[string]*FByteBulkData   `json:"-"`
+	Formats              []string                    `json:"formats"`
+	CompressedDataGuid   *FGuid                      `json:"compressed_data_guid"`
+	StreamedAudio        *FStreamedAudioPlatformData `json:"streamed_audio,omitempty"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Sound/SoundWave.h#L72
+type FStreamedAudioPlatformData struct {
+	AudioFormat string                 `json:"audio_format"`
+	Chunks      []*FStreamedAudioChunk `json:"-"`
+}
+
+type FStreamedAudioChunk struct {
+	Data          *FByteBulkData
+	DataSize      int32
+	AudioDataSize int32
+}
+
+// audioFormatPriority is the order in which the compressed formats of a sound are exported
+var audioFormatPriority = []string{"OGG", "OPUS", "ADPCM", "PCM"}
+
+func (parser *PakParser) ReadSoundWave(ctx context.Context, uAsset *FPackageFileSummary) *SoundWave {
+	// UObject guid
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+
+	sound := &SoundWave{
+		Cooked:               parser.ReadInt32() != 0,
+		CompressedFormatData: make(map[string]*FByteBulkData),
+		Formats:              make([]string, 0),
+	}
+
+	if property := parser.ExportProperty("bStreaming"); property != nil {
+		sound.Streaming, _ = property.TagData.(bool)
+	}
+
+	if property := parser.ExportProperty("SampleRate"); property != nil {
+		sound.SampleRate, _ = property.Tag.(int32)
+	}
+
+	if property := parser.ExportProperty("NumChannels"); property != nil {
+		sound.NumChannels, _ = property.Tag.(int32)
+	}
+
+	if !sound.Cooked {
+		log.Ctx(ctx).Warn().Msg("Uncooked SoundWave")
+		return sound
+	}
+
+	if !sound.Streaming {
+		count := parser.ReadInt32()
+
+		for i := int32(0); i < count; i++ {
+			format := strings.Trim(parser.ReadFName(uAsset.Names), "\x00")
+			sound.Formats = append(sound.Formats, format)
+			sound.CompressedFormatData[format] = parser.ReadFByteBulkData(uAsset)
+		}
+	}
+
+	sound.CompressedDataGuid = parser.ReadFGuid()
+
+	if sound.Streaming {
+		sound.StreamedAudio = parser.ReadFStreamedAudioPlatformData(uAsset)
+	}
+
+	return sound
+}
+
+func (parser *PakParser) ReadFStreamedAudioPlatformData(uAsset *FPackageFileSummary) *FStreamedAudioPlatformData {
+	count := parser.ReadInt32()
+
+	data := &FStreamedAudioPlatformData{
+		AudioFormat: strings.Trim(parser.ReadFName(uAsset.Names), "\x00"),
+		Chunks:      make([]*FStreamedAudioChunk, count),
+	}
+
+	for i := int32(0); i < count; i++ {
+		// Cooked flag
+		parser.ReadInt32()
+
+		data.Chunks[i] = &FStreamedAudioChunk{
+			Data:          parser.ReadFByteBulkData(uAsset),
+			DataSize:      parser.ReadInt32(),
+			AudioDataSize: parser.ReadInt32(),
+		}
+	}
+
+	return data
+}
+
+// Payload returns the compressed format and the data of the sound, joining the chunks of streamed sounds.
+// Returns nil data if the payload is not available.
+func (sound *SoundWave) Payload() (string, []byte) {
+	if sound.StreamedAudio != nil {
+		data := make([]byte, 0)
+
+		for _, chunk := range sound.StreamedAudio.Chunks {
+			chunkData := chunk.Data.Load()
+
+			if chunkData == nil {
+				return sound.StreamedAudio.AudioFormat, nil
+			}
+
+			if chunk.DataSize > 0 && int(chunk.DataSize) < len(chunkData) {
+				chunkData = chunkData[:chunk.DataSize]
+			}
+
+			data = append(data, chunkData...)
+		}
+
+		return sound.StreamedAudio.AudioFormat, data
+	}
+
+	for _, format := range audioFormatPriority {
+		if bulkData, ok := sound.CompressedFormatData[format]; ok {
+			if data := bulkData.Load(); data != nil {
+				return format, data
+			}
+		}
+	}
+
+	for _, format := range sound.Formats {
+		if data := sound.CompressedFormatData[format].Load(); data != nil {
+			return format, data
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,288 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const opusIdentifier = "UE4OPUS\x00"
+
+// adpcmBlockSize is the size of an ADPCM block per channel used by the ADPCM audio format
+const adpcmBlockSize = 512
+
+// Standard MS ADPCM coefficient pairs
+var adpcmCoefficients = [7][2]int16{
+	{256, 0}, {512, -256}, {0, 0}, {192, 64}, {240, 0}, {460, -208}, {392, -232},
+}
+
+// AudioExtension returns the file extension of the file written by WriteAudio
+func (sound *SoundWave) AudioExtension() string {
+	format, _ := sound.Payload()
+
+	switch format {
+	case "OGG":
+		return "ogg"
+	case "OPUS":
+		return "opus"
+	case "ADPCM", "PCM":
+		return "wav"
+	}
+
+	return strings.ToLower(format)
+}
+
+// WriteAudio writes the payload of the sound as a playable file.
+// Raw PCM and ADPCM are wrapped in a WAV header and Opus frames are wrapped in an Ogg container,
+// any other format is written as is.
+func (sound *SoundWave) WriteAudio(w io.Writer) error {
+	format, data := sound.Payload()
+
+	if data == nil {
+		return fmt.Errorf("sound has no available payload")
+	}
+
+	switch format {
+	case "ADPCM", "PCM":
+		if bytes.HasPrefix(data, []byte("RIFF")) {
+			break
+		}
+
+		return sound.writeWAV(w, format, data)
+	case "OPUS":
+		return writeOggOpus(w, data)
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+func (sound *SoundWave) writeWAV(w io.Writer, format string, data []byte) error {
+	if sound.NumChannels <= 0 || sound.SampleRate <= 0 {
+		return fmt.Errorf("sound is missing its channel count or sample rate")
+	}
+
+	channels := uint16(sound.NumChannels)
+
+	fmtChunk := &bytes.Buffer{}
+
+	if format == "PCM" {
+		blockAlign := channels * 2
+
+		writeLittleEndian(fmtChunk, uint16(1), channels, uint32(sound.SampleRate), uint32(sound.SampleRate)*uint32(blockAlign), blockAlign, uint16(16))
+	} else {
+		blockAlign := channels * adpcmBlockSize
+		samplesPerBlock := (adpcmBlockSize-7)*2 + 2
+
+		bytesPerSecond := uint32(sound.SampleRate) * uint32(blockAlign) / uint32(samplesPerBlock)
+
+		writeLittleEndian(fmtChunk, uint16(2), channels, uint32(sound.SampleRate), bytesPerSecond, blockAlign, uint16(4))
+		writeLittleEndian(fmtChunk, uint16(32), uint16(samplesPerBlock), uint16(len(adpcmCoefficients)), adpcmCoefficients)
+	}
+
+	header := &bytes.Buffer{}
+	header.WriteString("RIFF")
+	writeLittleEndian(header, uint32(4+8+fmtChunk.Len()+8+len(data)))
+	header.WriteString("WAVEfmt ")
+	writeLittleEndian(header, uint32(fmtChunk.Len()))
+	header.Write(fmtChunk.Bytes())
+	header.WriteString("data")
+	writeLittleEndian(header, uint32(len(data)))
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// writeOggOpus wraps the frames of the UE4 Opus format in an Ogg Opus stream
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Developer/AudioFormatOpus/Private/AudioFormatOpus.cpp#L367
+func writeOggOpus(w io.Writer, data []byte) error {
+	if len(data) < len(opusIdentifier)+9 || string(data[:len(opusIdentifier)]) != opusIdentifier {
+		return fmt.Errorf("invalid opus header")
+	}
+
+	offset := len(opusIdentifier)
+	sampleRate := binary.LittleEndian.Uint16(data[offset:])
+	trueSampleCount := binary.LittleEndian.Uint32(data[offset+2:])
+	channels := data[offset+6]
+	// The frame count is skipped as it does not fit long streamed sounds, frames are read until the end instead
+	offset += 9
+
+	if channels > 2 {
+		return fmt.Errorf("opus sounds with %d channels are not supported", channels)
+	}
+
+	ogg := &oggWriter{w: w, serial: 0x55453450}
+
+	head := &bytes.Buffer{}
+	head.WriteString("OpusHead")
+	writeLittleEndian(head, uint8(1), channels, uint16(0), uint32(sampleRate), int16(0), uint8(0))
+
+	if err := ogg.writePage(head.Bytes(), 0, oggBeginningOfStream); err != nil {
+		return err
+	}
+
+	tags := &bytes.Buffer{}
+	tags.WriteString("OpusTags")
+	writeLittleEndian(tags, uint32(6), []byte("ue4pak"), uint32(0))
+
+	if err := ogg.writePage(tags.Bytes(), 0, 0); err != nil {
+		return err
+	}
+
+	// Granule positions are always counted at 48kHz
+	lastGranule := uint64(trueSampleCount)
+	if sampleRate > 0 {
+		lastGranule = uint64(trueSampleCount) * 48000 / uint64(sampleRate)
+	}
+
+	granule := uint64(0)
+
+	for i := 0; offset < len(data); i++ {
+		if offset+2 > len(data) {
+			return fmt.Errorf("opus frame %d is truncated", i)
+		}
+
+		frameSize := int(binary.LittleEndian.Uint16(data[offset:]))
+		offset += 2
+
+		if offset+frameSize > len(data) {
+			return fmt.Errorf("opus frame %d is truncated", i)
+		}
+
+		frame := data[offset : offset+frameSize]
+		offset += frameSize
+
+		granule += uint64(opusPacketSamples(frame))
+
+		flags := byte(0)
+		if offset == len(data) {
+			flags = oggEndOfStream
+
+			if lastGranule < granule {
+				granule = lastGranule
+			}
+		}
+
+		if err := ogg.writePage(frame, granule, flags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// opusPacketSamples returns the amount of 48kHz samples in an Opus packet
+func opusPacketSamples(packet []byte) int {
+	if len(packet) == 0 {
+		return 0
+	}
+
+	config := packet[0] >> 3
+
+	var frameSamples int
+	switch {
+	case config < 12:
+		frameSamples = []int{480, 960, 1920, 2880}[config%4]
+	case config < 16:
+		frameSamples = []int{480, 960}[config%2]
+	default:
+		frameSamples = []int{120, 240, 480, 960}[config%4]
+	}
+
+	switch packet[0] & 3 {
+	case 0:
+		return frameSamples
+	case 1, 2:
+		return frameSamples * 2
+	}
+
+	if len(packet) < 2 {
+		return 0
+	}
+
+	return frameSamples * int(packet[1]&0x3f)
+}
+
+const (
+	oggBeginningOfStream = 0x02
+	oggEndOfStream       = 0x04
+)
+
+type oggWriter struct {
+	w        io.Writer
+	serial   uint32
+	sequence uint32
+}
+
+// writePage writes a single packet as its own page
+func (ogg *oggWriter) writePage(packet []byte, granule uint64, flags byte) error {
+	segments := len(packet)/255 + 1
+
+	if segments > 255 {
+		return fmt.Errorf("ogg packet of %d bytes is too large", len(packet))
+	}
+
+	page := &bytes.Buffer{}
+	page.WriteString("OggS")
+	page.WriteByte(0)
+	page.WriteByte(flags)
+	writeLittleEndian(page, granule, ogg.serial, ogg.sequence, uint32(0), uint8(segments))
+
+	for i := 0; i < segments-1; i++ {
+		page.WriteByte(255)
+	}
+	page.WriteByte(byte(len(packet) % 255))
+	page.Write(packet)
+
+	pageData := page.Bytes()
+	binary.LittleEndian.PutUint32(pageData[22:], oggChecksum(pageData))
+
+	ogg.sequence++
+
+	_, err := ogg.w.Write(pageData)
+	return err
+}
+
+var oggCRCTable = func() [256]uint32 {
+	var table [256]uint32
+
+	for i := range table {
+		crc := uint32(i) << 24
+
+		for j := 0; j < 8; j++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+
+		table[i] = crc
+	}
+
+	return table
+}()
+
+func oggChecksum(data []byte) uint32 {
+	crc := uint32(0)
+
+	for _, b := range data {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+
+	return crc
+}
+
+func writeLittleEndian(w io.Writer, values ...interface{}) {
+	for _, value := range values {
+		if err := binary.Write(w, binary.LittleEndian, value); err != nil {
+			panic(err)
+		}
+	}
+}
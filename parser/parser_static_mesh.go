@@ -0,0 +1,286 @@
+package parser
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ClassStripAdjacencyData       = 0x1
+	ClassStripMinLodData          = 0x2
+	ClassStripReversedIndexBuffer = 0x4
+
+	// ClassStripDistanceFieldData is the class strip flag of the distance fields of static mesh render data
+	ClassStripDistanceFieldData = 0x1
+
+	maxStaticMeshLODs = 8
+
+	// renderingStaticMeshSectionForceOpaqueField is FRenderingObjectVersion::StaticMeshSectionForceOpaqueField
+	renderingStaticMeshSectionForceOpaqueField = 33
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/UObject/RenderingObjectVersion.h
+var renderingObjectVersion = FGuid{A: 0x12F88B9F, B: 0x88754AFC, C: 0xA67CD90C, D: 0x383ABD29}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/StaticMesh.cpp
+type StaticMesh struct {
+	Cooked          bool                   `json:"cooked"`
+	BodySetup       *FPackageIndex         `json:"body_setup"`
+	NavCollision    *FPackageIndex         `json:"nav_collision"`
+	LightingGuid    *FGuid                 `json:"lighting_guid"`
+	Sockets         []*FPackageIndex       `json:"sockets"`
+	RenderData      *FStaticMeshRenderData `json:"render_data"`
+	StaticMaterials []*FStaticMaterial     `json:"static_materials"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/StaticMeshResources.h
+type FStaticMeshRenderData struct {
+	LODs                    []*FStaticMeshLODResources `json:"lods"`
+	Bounds                  *FBoxSphereBounds          `json:"bounds"`
+	LODsShareStaticLighting bool                       `json:"lods_share_static_lighting"`
+	ScreenSize              []float32                  `json:"screen_size"`
+}
+
+type FStaticMeshLODResources struct {
+	Sections             []*FStaticMeshSection    `json:"sections"`
+	MaxDeviation         float32                  `json:"max_deviation"`
+	PositionVertexBuffer *FPositionVertexBuffer   `json:"position_vertex_buffer"`
+	VertexBuffer         *FStaticMeshVertexBuffer `json:"vertex_buffer"`
+	ColorVertexBuffer    *FColorVertexBuffer      `json:"color_vertex_buffer"`
+	IndexBuffer          *FRawStaticIndexBuffer   `json:"index_buffer"`
+}
+
+type FStaticMeshSection struct {
+	MaterialIndex   int32 `json:"material_index"`
+	FirstIndex      int32 `json:"first_index"`
+	NumTriangles    int32 `json:"num_triangles"`
+	MinVertexIndex  int32 `json:"min_vertex_index"`
+	MaxVertexIndex  int32 `json:"max_vertex_index"`
+	EnableCollision bool  `json:"enable_collision"`
+	CastShadow      bool  `json:"cast_shadow"`
+	ForceOpaque     bool  `json:"force_opaque"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Engine/StaticMesh.h
+type FStaticMaterial struct {
+	MaterialInterface *FPackageIndex      `json:"material_interface"`
+	MaterialSlotName  string              `json:"material_slot_name"`
+	UVChannelData     *FMeshUVChannelInfo `json:"uv_channel_data"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Engine/EngineTypes.h
+type FMeshUVChannelInfo struct {
+	Initialized       bool       `json:"initialized"`
+	OverrideDensities bool       `json:"override_densities"`
+	LocalUVDensities  [4]float32 `json:"local_uv_densities"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Math/BoxSphereBounds.h
+type FBoxSphereBounds struct {
+	Origin       *FVector `json:"origin"`
+	BoxExtent    *FVector `json:"box_extent"`
+	SphereRadius float32  `json:"sphere_radius"`
+}
+
+func (parser *PakParser) ReadFBoxSphereBounds() *FBoxSphereBounds {
+	return &FBoxSphereBounds{
+		Origin:       parser.ReadFVector(),
+		BoxExtent:    parser.ReadFVector(),
+		SphereRadius: parser.ReadFloat32(),
+	}
+}
+
+func (parser *PakParser) ReadStaticMesh(ctx context.Context, uAsset *FPackageFileSummary) *StaticMesh {
+	// UObject guid
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+
+	stripFlags := parser.ReadFStripDataFlags()
+
+	mesh := &StaticMesh{
+		Cooked:       parser.ReadInt32() != 0,
+		BodySetup:    parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports),
+		NavCollision: parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports),
+	}
+
+	if !stripFlags.IsEditorDataStripped() || !mesh.Cooked {
+		log.Ctx(ctx).Warn().Msg("Uncooked StaticMesh")
+		return mesh
+	}
+
+	mesh.LightingGuid = parser.ReadFGuid()
+
+	socketCount := parser.ReadInt32()
+	mesh.Sockets = make([]*FPackageIndex, socketCount)
+	for i := int32(0); i < socketCount; i++ {
+		mesh.Sockets[i] = parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports)
+	}
+
+	mesh.RenderData = parser.ReadFStaticMeshRenderData(uAsset)
+
+	// Occluder data
+	if parser.ReadInt32() != 0 {
+		parser.Read(parser.ReadInt32() * 12)
+		parser.Read(parser.ReadInt32() * 2)
+	}
+
+	// SpeedTree wind data is not supported, the materials are serialized after it
+	if parser.ReadInt32() != 0 {
+		log.Ctx(ctx).Warn().Msg("StaticMesh with SpeedTree wind is not supported")
+		return mesh
+	}
+
+	materialCount := parser.ReadInt32()
+	mesh.StaticMaterials = make([]*FStaticMaterial, materialCount)
+	for i := int32(0); i < materialCount; i++ {
+		mesh.StaticMaterials[i] = parser.ReadFStaticMaterial(uAsset)
+	}
+
+	return mesh
+}
+
+func (parser *PakParser) ReadFStaticMeshRenderData(uAsset *FPackageFileSummary) *FStaticMeshRenderData {
+	lodCount := parser.ReadInt32()
+
+	renderData := &FStaticMeshRenderData{
+		LODs:       make([]*FStaticMeshLODResources, lodCount),
+		ScreenSize: make([]float32, maxStaticMeshLODs),
+	}
+
+	for i := int32(0); i < lodCount; i++ {
+		renderData.LODs[i] = parser.ReadFStaticMeshLODResources(uAsset)
+	}
+
+	stripFlags := parser.ReadFStripDataFlags()
+
+	if !stripFlags.IsDataStrippedForServer() && !stripFlags.IsClassDataStripped(ClassStripDistanceFieldData) {
+		for i := int32(0); i < lodCount; i++ {
+			if parser.ReadInt32() != 0 {
+				parser.skipFDistanceFieldVolumeData()
+			}
+		}
+	}
+
+	renderData.Bounds = parser.ReadFBoxSphereBounds()
+	renderData.LODsShareStaticLighting = parser.ReadInt32() != 0
+
+	for i := range renderData.ScreenSize {
+		// Cooked flag of the per platform screen size
+		parser.ReadInt32()
+		renderData.ScreenSize[i] = parser.ReadFloat32()
+	}
+
+	return renderData
+}
+
+func (parser *PakParser) ReadFStaticMeshLODResources(uAsset *FPackageFileSummary) *FStaticMeshLODResources {
+	stripFlags := parser.ReadFStripDataFlags()
+
+	sectionCount := parser.ReadInt32()
+
+	lod := &FStaticMeshLODResources{
+		Sections: make([]*FStaticMeshSection, sectionCount),
+	}
+
+	for i := int32(0); i < sectionCount; i++ {
+		lod.Sections[i] = parser.ReadFStaticMeshSection(uAsset)
+	}
+
+	lod.MaxDeviation = parser.ReadFloat32()
+
+	if stripFlags.IsDataStrippedForServer() || stripFlags.IsClassDataStripped(ClassStripMinLodData) {
+		return lod
+	}
+
+	lod.PositionVertexBuffer = parser.ReadFPositionVertexBuffer()
+	lod.VertexBuffer = parser.ReadFStaticMeshVertexBuffer()
+	lod.ColorVertexBuffer = parser.ReadFColorVertexBuffer()
+	lod.IndexBuffer = parser.ReadFRawStaticIndexBuffer()
+
+	// Reversed, depth only and reversed depth only index buffers
+	if !stripFlags.IsClassDataStripped(ClassStripReversedIndexBuffer) {
+		parser.ReadFRawStaticIndexBuffer()
+		parser.ReadFRawStaticIndexBuffer()
+		parser.ReadFRawStaticIndexBuffer()
+	} else {
+		parser.ReadFRawStaticIndexBuffer()
+	}
+
+	// Wireframe index buffer
+	if !stripFlags.IsEditorDataStripped() {
+		parser.ReadFRawStaticIndexBuffer()
+	}
+
+	// Adjacency index buffer
+	if !stripFlags.IsClassDataStripped(ClassStripAdjacencyData) {
+		parser.ReadFRawStaticIndexBuffer()
+	}
+
+	// Area weighted samplers of every section and of the whole LOD
+	for i := int32(0); i <= sectionCount; i++ {
+		parser.skipFWeightedRandomSampler()
+	}
+
+	return lod
+}
+
+func (parser *PakParser) ReadFStaticMeshSection(uAsset *FPackageFileSummary) *FStaticMeshSection {
+	section := &FStaticMeshSection{
+		MaterialIndex:   parser.ReadInt32(),
+		FirstIndex:      parser.ReadInt32(),
+		NumTriangles:    parser.ReadInt32(),
+		MinVertexIndex:  parser.ReadInt32(),
+		MaxVertexIndex:  parser.ReadInt32(),
+		EnableCollision: parser.ReadInt32() != 0,
+		CastShadow:      parser.ReadInt32() != 0,
+	}
+
+	version, ok := uAsset.CustomVersion(renderingObjectVersion)
+
+	// Unversioned packages are saved with the latest custom versions of the engine
+	if !ok && uAsset.Unversioned {
+		version, ok = renderingStaticMeshSectionForceOpaqueField, true
+	}
+
+	// Force opaque was added for ray tracing
+	if ok && version >= renderingStaticMeshSectionForceOpaqueField {
+		section.ForceOpaque = parser.ReadInt32() != 0
+	}
+
+	return section
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/DistanceFieldAtlas.h
+func (parser *PakParser) skipFDistanceFieldVolumeData() {
+	parser.Read(parser.ReadInt32()) // CompressedDistanceFieldVolume
+	parser.ReadFIntVector()         // Size
+	parser.ReadFBox()               // LocalBoundingBox
+	parser.ReadFVector2D()          // DistanceMinMax
+	parser.ReadInt32()              // bMeshWasClosed
+	parser.ReadInt32()              // bBuiltAsIfTwoSided
+	parser.ReadInt32()              // bMeshWasPlane
+}
+
+func (parser *PakParser) ReadFStaticMaterial(uAsset *FPackageFileSummary) *FStaticMaterial {
+	return &FStaticMaterial{
+		MaterialInterface: parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports),
+		MaterialSlotName:  strings.Trim(parser.ReadFName(uAsset.Names), "\x00"),
+		UVChannelData:     parser.ReadFMeshUVChannelInfo(),
+	}
+}
+
+func (parser *PakParser) ReadFMeshUVChannelInfo() *FMeshUVChannelInfo {
+	info := &FMeshUVChannelInfo{
+		Initialized:       parser.ReadInt32() != 0,
+		OverrideDensities: parser.ReadInt32() != 0,
+	}
+
+	for i := range info.LocalUVDensities {
+		info.LocalUVDensities[i] = parser.ReadFloat32()
+	}
+
+	return info
+}
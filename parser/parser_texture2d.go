@@ -2,16 +2,22 @@ package parser
 
 import (
 	"context"
+	"encoding/binary"
 	"github.com/rs/zerolog/log"
 	"github.com/spate/glimage"
 	"github.com/x448/float16"
 	"image"
+	"math"
 	"strings"
 )
 
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/Texture2D.cpp#L336
 type Texture2D struct {
-	Cooked   uint32
-	Textures []*FTexturePlatformData
+	Cooked   uint32                  `json:"cooked"`
+	Textures []*FTexturePlatformData `json:"textures"`
+
+	// IsCube is set for TextureCube exports, whose slices are the faces of the cubemaps
+	IsCube bool `json:"is_cube"`
 }
 
 type FTexturePlatformData struct {
@@ -31,19 +37,45 @@ type FTexture2DMipMap struct {
 	SizeZ int32
 }
 
-type FByteBulkData struct {
-	Header *FByteBulkDataHeader
-	Data   []byte
-}
+func (parser *PakParser) ReadTexture2D(ctx context.Context, uAsset *FPackageFileSummary) *Texture2D {
+	// UObject guid
+	if parser.ReadInt32() != 0 {
+		parser.ReadFGuid()
+	}
+
+	// UTexture and UTexture2D strip flags
+	parser.Read(2)
+	parser.Read(2)
+
+	texture := &Texture2D{
+		Cooked:   parser.ReadUint32(),
+		Textures: make([]*FTexturePlatformData, 0),
+	}
+
+	if texture.Cooked != 1 {
+		log.Ctx(ctx).Warn().Msg("Uncooked Texture2D")
+		return texture
+	}
+
+	pixelFormat := parser.ReadFName(uAsset.Names)
 
-type FByteBulkDataHeader struct {
-	BulkDataFlags int32
-	ElementCount  int32
-	SizeOnDisk    int32
-	OffsetInFile  int64
+	for strings.Trim(pixelFormat, "\x00") != "None" {
+		parser.ReadInt64() // SkipOffset
+		platformData := parser.ReadFTexturePlatformData(ctx, uAsset)
+		texture.Textures = append(texture.Textures, platformData)
+
+		// The virtual texture data that follows cannot be read, leaving the rest of the export undecoded
+		if platformData.IsVirtual {
+			break
+		}
+
+		pixelFormat = parser.ReadFName(uAsset.Names)
+	}
+
+	return texture
 }
 
-func (parser *PakParser) ReadFTexturePlatformData(ctx context.Context, bulkOffset int64) *FTexturePlatformData {
+func (parser *PakParser) ReadFTexturePlatformData(ctx context.Context, uAsset *FPackageFileSummary) *FTexturePlatformData {
 	data := &FTexturePlatformData{
 		SizeX:       parser.ReadInt32(),
 		SizeY:       parser.ReadInt32(),
@@ -56,17 +88,27 @@ func (parser *PakParser) ReadFTexturePlatformData(ctx context.Context, bulkOffse
 	data.Mips = make([]*FTexture2DMipMap, length)
 
 	for i := uint32(0); i < length; i++ {
-		data.Mips[i] = parser.ReadFTexture2DMipMap(ctx, bulkOffset)
+		data.Mips[i] = parser.ReadFTexture2DMipMap(ctx, uAsset)
+	}
+
+	// Virtual textures were added in 4.23
+	if uAsset.SavedByEngineVersion != nil && uAsset.SavedByEngineVersion.AtLeast(4, 23) {
+		data.IsVirtual = parser.ReadInt32() != 0
+
+		if data.IsVirtual {
+			log.Ctx(ctx).Warn().Msg("Virtual textures are not supported, skipping mips")
+			data.Mips = nil
+		}
 	}
 
 	return data
 }
 
-func (parser *PakParser) ReadFTexture2DMipMap(ctx context.Context, bulkOffset int64) *FTexture2DMipMap {
+func (parser *PakParser) ReadFTexture2DMipMap(ctx context.Context, uAsset *FPackageFileSummary) *FTexture2DMipMap {
 	cooked := parser.ReadInt32()
 
 	mipMap := &FTexture2DMipMap{
-		Data:  parser.ReadFByteBulkData(bulkOffset),
+		Data:  parser.ReadFByteBulkData(uAsset),
 		SizeX: parser.ReadInt32(),
 		SizeY: parser.ReadInt32(),
 		SizeZ: parser.ReadInt32(),
@@ -80,54 +122,130 @@ func (parser *PakParser) ReadFTexture2DMipMap(ctx context.Context, bulkOffset in
 	return mipMap
 }
 
-func (parser *PakParser) ReadFByteBulkData(bulkOffset int64) *FByteBulkData {
-	header := parser.ReadFByteBulkDataHeader()
+// Mip returns the mip at the index, or the largest loaded mip if the index is negative
+func (texture *Texture2D) Mip(index int) *FTexture2DMipMap {
+	if len(texture.Textures) == 0 {
+		return nil
+	}
+
+	mips := texture.Textures[0].Mips
 
-	var data []byte
+	if index >= 0 {
+		if index >= len(mips) || mips[index] == nil || mips[index].Data.Load() == nil {
+			return nil
+		}
 
-	if header.BulkDataFlags&0x0040 != 0 {
-		data = parser.Read(header.ElementCount)
+		return mips[index]
 	}
 
-	if header.BulkDataFlags&0x0100 != 0 {
-		panic("TODO") // TODO
+	for _, mipMap := range mips {
+		if mipMap != nil && mipMap.Data.Load() != nil {
+			return mipMap
+		}
 	}
 
-	return &FByteBulkData{
-		Header: header,
-		Data:   data,
-	}
+	return nil
 }
 
-func (parser *PakParser) ReadFByteBulkDataHeader() *FByteBulkDataHeader {
-	return &FByteBulkDataHeader{
-		BulkDataFlags: parser.ReadInt32(),
-		ElementCount:  parser.ReadInt32(),
-		SizeOnDisk:    parser.ReadInt32(),
-		OffsetInFile:  parser.ReadInt64(),
+// Slices returns the amount of slices of texture arrays and cubemaps, 1 for regular textures
+func (texture *Texture2D) Slices(mipMap *FTexture2DMipMap) int32 {
+	slices := int32(1)
+
+	if len(texture.Textures) > 0 && texture.Textures[0].NumSlices > slices {
+		slices = texture.Textures[0].NumSlices
+	}
+
+	if mipMap.SizeZ > slices {
+		slices = mipMap.SizeZ
 	}
+
+	return slices
 }
 
 func (texture *Texture2D) ToImage(ctx context.Context) image.Image {
-	mipMap := texture.Textures[0].Mips[0]
+	images := texture.ToImages(ctx, -1)
+
+	if len(images) == 0 {
+		return nil
+	}
+
+	return images[0]
+}
+
+// ToImages decodes every slice of the mip at the index, or of the largest loaded mip if the index is negative
+func (texture *Texture2D) ToImages(ctx context.Context, mipIndex int) []image.Image {
+	mipMap := texture.Mip(mipIndex)
+
+	if mipMap == nil {
+		log.Ctx(ctx).Error().Msgf("Texture2D has no loaded mip %d", mipIndex)
+		return nil
+	}
+
+	pixelFormat := strings.Trim(texture.Textures[0].PixelFormat, "\x00")
+	slices := texture.Slices(mipMap)
+	sliceSize := int32(len(mipMap.Data.Data)) / slices
+
+	images := make([]image.Image, slices)
+	for i := int32(0); i < slices; i++ {
+		img, ok := DecodePixelFormat(pixelFormat, mipMap.Data.Data[i*sliceSize:(i+1)*sliceSize], mipMap.SizeX, mipMap.SizeY)
+
+		if !ok {
+			log.Ctx(ctx).Error().Msgf("Unknown Texture2D pixel format: %s", pixelFormat)
+			return nil
+		}
 
-	switch strings.Trim(texture.Textures[0].PixelFormat, "\x00") {
+		images[i] = img
+	}
+
+	return images
+}
+
+var astcBlockSizes = map[string][2]int32{
+	"PF_ASTC_4x4":   {4, 4},
+	"PF_ASTC_6x6":   {6, 6},
+	"PF_ASTC_8x8":   {8, 8},
+	"PF_ASTC_10x10": {10, 10},
+	"PF_ASTC_12x12": {12, 12},
+}
+
+// DecodePixelFormat decodes an image of the pixel format, returning false if the format is unsupported
+func DecodePixelFormat(pixelFormat string, data []byte, width int32, height int32) (image.Image, bool) {
+	switch pixelFormat {
 	case "PF_DXT1":
-		return DecodeDXT1(mipMap.Data.Data, mipMap.SizeX, mipMap.SizeY)
+		return DecodeDXT1(data, width, height), true
 	case "PF_DXT3":
-		return DecodeDXT3(mipMap.Data.Data, mipMap.SizeX, mipMap.SizeY)
+		return DecodeDXT3(data, width, height), true
 	case "PF_DXT5":
-		return DecodeDXT5(mipMap.Data.Data, mipMap.SizeX, mipMap.SizeY)
+		return DecodeDXT5(data, width, height), true
+	case "PF_BC4":
+		return DecodeBC4(data, width, height), true
+	case "PF_BC5":
+		return DecodeBC5(data, width, height), true
+	case "PF_BC6H":
+		return DecodeBC6H(data, width, height), true
+	case "PF_BC7":
+		return DecodeBC7(data, width, height), true
 	case "PF_B8G8R8A8":
-		return DecodeBGRA(mipMap.Data.Data, mipMap.SizeX, mipMap.SizeY)
+		return DecodeBGRA(data, width, height), true
+	case "PF_R8G8B8A8":
+		return DecodeRGBA(data, width, height), true
 	case "PF_G8":
-		return DecodeG8(mipMap.Data.Data, mipMap.SizeX, mipMap.SizeY)
+		return DecodeG8(data, width, height), true
+	case "PF_G16":
+		return DecodeG16(data, width, height), true
 	case "PF_FloatRGBA":
-		return DecodeFloatRGBA(mipMap.Data.Data, mipMap.SizeX, mipMap.SizeY)
-	default:
-		log.Ctx(ctx).Error().Msgf("Unknown Texture2D pixel format: %s", strings.Trim(texture.Textures[0].PixelFormat, "\x00"))
-		return nil
+		return DecodeFloatRGBA(data, width, height), true
+	case "PF_A32B32G32R32F":
+		return DecodeFloat32RGBA(data, width, height), true
+	case "PF_R16F":
+		return DecodeR16F(data, width, height), true
+	}
+
+	if blockSize, ok := astcBlockSizes[pixelFormat]; ok {
+		return DecodeASTC(data, width, height, blockSize[0], blockSize[1]), true
 	}
+
+	return nil, false
 }
 
 func DecodeDXT1(data []byte, width int32, height int32) image.Image {
@@ -160,15 +278,56 @@ func DecodeG8(data []byte, width int32, height int32) image.Image {
 	return img
 }
 
+func DecodeRGBA(data []byte, width int32, height int32) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, int(width), int(height)))
+	copy(img.Pix, data)
+	return img
+}
+
+func DecodeG16(data []byte, width int32, height int32) image.Image {
+	img := image.NewGray16(image.Rect(0, 0, int(width), int(height)))
+
+	// Gray16 is stored big endian
+	for i := 0; i+1 < len(img.Pix) && i+1 < len(data); i += 2 {
+		img.Pix[i] = data[i+1]
+		img.Pix[i+1] = data[i]
+	}
+
+	return img
+}
+
 func DecodeFloatRGBA(data []byte, width int32, height int32) image.Image {
 	newData := make([]byte, width*height*4)
 
-	for i := int32(0); i < width*height*4; i++ {
-		newData[i] = uint8(float16.Frombits(uint16(data[i*2+1])<<8|uint16(data[i*2])).Float32() * 255)
+	for i := 0; i < len(newData) && i*2+1 < len(data); i++ {
+		newData[i] = clampUnitFloat(float16.Frombits(uint16(data[i*2+1])<<8 | uint16(data[i*2])).Float32())
 	}
 
-	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	img := image.NewNRGBA(image.Rect(0, 0, int(width), int(height)))
 	img.Pix = newData
 
 	return img
 }
+
+func DecodeFloat32RGBA(data []byte, width int32, height int32) image.Image {
+	newData := make([]byte, width*height*4)
+
+	for i := 0; i < len(newData) && i*4+3 < len(data); i++ {
+		newData[i] = clampUnitFloat(math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:])))
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, int(width), int(height)))
+	img.Pix = newData
+
+	return img
+}
+
+func DecodeR16F(data []byte, width int32, height int32) image.Image {
+	img := image.NewGray(image.Rect(0, 0, int(width), int(height)))
+
+	for i := 0; i < len(img.Pix) && i*2+1 < len(data); i++ {
+		img.Pix[i] = clampUnitFloat(float16.Frombits(uint16(data[i*2+1])<<8 | uint16(data[i*2])).Float32())
+	}
+
+	return img
+}
@@ -0,0 +1,836 @@
+package parser
+
+import (
+	"image"
+	"image/color"
+)
+
+// https://www.khronos.org/registry/DataFormat/specs/1.3/dataformat.1.3.html#ASTC
+
+var astcErrorColor = color.NRGBA{R: 255, B: 255, A: 255}
+
+// astcRanges are the value ranges that integer sequences can be quantized to, in order of quantization level
+var astcRanges = []struct {
+	Trits  bool
+	Quints bool
+	Bits   uint
+}{
+	{Bits: 1},               // 2
+	{Trits: true},           // 3
+	{Bits: 2},               // 4
+	{Quints: true},          // 5
+	{Trits: true, Bits: 1},  // 6
+	{Bits: 3},               // 8
+	{Quints: true, Bits: 1}, // 10
+	{Trits: true, Bits: 2},  // 12
+	{Bits: 4},               // 16
+	{Quints: true, Bits: 2}, // 20
+	{Trits: true, Bits: 3},  // 24
+	{Bits: 5},               // 32
+	{Quints: true, Bits: 3}, // 40
+	{Trits: true, Bits: 4},  // 48
+	{Bits: 6},               // 64
+	{Quints: true, Bits: 4}, // 80
+	{Trits: true, Bits: 5},  // 96
+	{Bits: 7},               // 128
+	{Quints: true, Bits: 5}, // 160
+	{Trits: true, Bits: 6},  // 192
+	{Bits: 8},               // 256
+}
+
+// astcBits is a 128 bit block that can be read at arbitrary positions
+type astcBits [16]byte
+
+func (bits *astcBits) read(position int, count int) uint32 {
+	value := uint32(0)
+
+	for i := 0; i < count; i++ {
+		bit := position + i
+		value |= uint32(bits[bit>>3]>>(bit&7)&1) << i
+	}
+
+	return value
+}
+
+func (bits *astcBits) reversed() *astcBits {
+	result := &astcBits{}
+
+	for i := 0; i < 16; i++ {
+		b := bits[15-i]
+		b = (b&0xF0)>>4 | (b&0x0F)<<4
+		b = (b&0xCC)>>2 | (b&0x33)<<2
+		b = (b&0xAA)>>1 | (b&0x55)<<1
+		result[i] = b
+	}
+
+	return result
+}
+
+func astcSequenceBits(count int, level int) int {
+	encoding := astcRanges[level]
+	bits := count * int(encoding.Bits)
+
+	if encoding.Trits {
+		bits += (count*8 + 4) / 5
+	} else if encoding.Quints {
+		bits += (count*7 + 2) / 3
+	}
+
+	return bits
+}
+
+// decodeASTCSequence decodes an integer sequence encoded with trits, quints or plain bits
+func decodeASTCSequence(bits *astcBits, position int, count int, level int) []uint32 {
+	encoding := astcRanges[level]
+	values := make([]uint32, count)
+	mask := uint32(1)<<encoding.Bits - 1
+
+	// Reads beyond the sequence end are treated as zero
+	end := position + astcSequenceBits(count, level)
+	read := func(n int) uint32 {
+		if n <= 0 || position >= end {
+			position += n
+			return 0
+		}
+
+		available := n
+		if position+n > end {
+			available = end - position
+		}
+
+		value := bits.read(position, available)
+		position += n
+
+		return value
+	}
+
+	switch {
+	case encoding.Trits:
+		for i := 0; i < count; i += 5 {
+			var low [5]uint32
+			var t uint32
+
+			low[0] = read(int(encoding.Bits))
+			t |= read(2)
+			low[1] = read(int(encoding.Bits))
+			t |= read(2) << 2
+			low[2] = read(int(encoding.Bits))
+			t |= read(1) << 4
+			low[3] = read(int(encoding.Bits))
+			t |= read(2) << 5
+			low[4] = read(int(encoding.Bits))
+			t |= read(1) << 7
+
+			trits := decodeTrits(t)
+			for j := 0; j < 5 && i+j < count; j++ {
+				values[i+j] = trits[j]<<encoding.Bits | low[j]&mask
+			}
+		}
+	case encoding.Quints:
+		for i := 0; i < count; i += 3 {
+			var low [3]uint32
+			var q uint32
+
+			low[0] = read(int(encoding.Bits))
+			q |= read(3)
+			low[1] = read(int(encoding.Bits))
+			q |= read(2) << 3
+			low[2] = read(int(encoding.Bits))
+			q |= read(2) << 5
+
+			quints := decodeQuints(q)
+			for j := 0; j < 3 && i+j < count; j++ {
+				values[i+j] = quints[j]<<encoding.Bits | low[j]&mask
+			}
+		}
+	default:
+		for i := range values {
+			values[i] = read(int(encoding.Bits))
+		}
+	}
+
+	return values
+}
+
+// replicateBits repeats the bits of the value until it is the target width
+func replicateBits(value uint32, bits uint, target uint) int32 {
+	result := uint32(0)
+	filled := uint(0)
+
+	for filled < target {
+		result = result<<bits | value
+		filled += bits
+	}
+
+	return int32(result >> (filled - target))
+}
+
+func bitsOf(value uint32, high uint, low uint) uint32 {
+	return (value >> low) & (1<<(high-low+1) - 1)
+}
+
+func decodeTrits(t uint32) [5]uint32 {
+	var trits [5]uint32
+	var c uint32
+
+	if bitsOf(t, 4, 2) == 7 {
+		c = bitsOf(t, 7, 5)<<2 | bitsOf(t, 1, 0)
+		trits[4] = 2
+		trits[3] = 2
+	} else {
+		c = bitsOf(t, 4, 0)
+		if bitsOf(t, 6, 5) == 3 {
+			trits[4] = 2
+			trits[3] = bitsOf(t, 7, 7)
+		} else {
+			trits[4] = bitsOf(t, 7, 7)
+			trits[3] = bitsOf(t, 6, 5)
+		}
+	}
+
+	if bitsOf(c, 1, 0) == 3 {
+		trits[2] = 2
+		trits[1] = bitsOf(c, 4, 4)
+		trits[0] = bitsOf(c, 3, 3)<<1 | (bitsOf(c, 2, 2) &^ bitsOf(c, 3, 3))
+	} else if bitsOf(c, 3, 2) == 3 {
+		trits[2] = 2
+		trits[1] = 2
+		trits[0] = bitsOf(c, 1, 0)
+	} else {
+		trits[2] = bitsOf(c, 4, 4)
+		trits[1] = bitsOf(c, 3, 2)
+		trits[0] = bitsOf(c, 1, 1)<<1 | (bitsOf(c, 0, 0) &^ bitsOf(c, 1, 1))
+	}
+
+	return trits
+}
+
+func decodeQuints(q uint32) [3]uint32 {
+	var quints [3]uint32
+
+	if bitsOf(q, 2, 1) == 3 && bitsOf(q, 6, 5) == 0 {
+		q0 := bitsOf(q, 0, 0)
+		quints[2] = q0<<2 | (bitsOf(q, 4, 4)&^q0)<<1 | bitsOf(q, 3, 3)&^q0
+		quints[1] = 4
+		quints[0] = 4
+		return quints
+	}
+
+	var c uint32
+	if bitsOf(q, 2, 1) == 3 {
+		quints[2] = 4
+		c = bitsOf(q, 4, 3)<<3 | (^bitsOf(q, 6, 5)&3)<<1 | bitsOf(q, 0, 0)
+	} else {
+		quints[2] = bitsOf(q, 6, 5)
+		c = bitsOf(q, 4, 0)
+	}
+
+	if bitsOf(c, 2, 0) == 5 {
+		quints[1] = 4
+		quints[0] = bitsOf(c, 4, 3)
+	} else {
+		quints[1] = bitsOf(c, 4, 3)
+		quints[0] = bitsOf(c, 2, 0)
+	}
+
+	return quints
+}
+
+// unquantizeASTCColor maps a quantized endpoint value to the range [0, 255]
+func unquantizeASTCColor(value uint32, level int) int32 {
+	encoding := astcRanges[level]
+
+	if !encoding.Trits && !encoding.Quints {
+		return replicateBits(value, encoding.Bits, 8)
+	}
+
+	bits := encoding.Bits
+	a := int32(0)
+	if value&1 != 0 {
+		a = 0x1FF
+	}
+
+	// The value bits above the lowest one, b is bit 1, c is bit 2 and so on
+	bit := func(n uint) int32 {
+		return int32(value>>n) & 1
+	}
+
+	var b, c int32
+
+	if encoding.Trits {
+		switch bits {
+		case 1:
+			c = 204
+		case 2:
+			b = bit(1)<<8 | bit(1)<<4 | bit(1)<<2 | bit(1)<<1
+			c = 93
+		case 3:
+			b = bit(2)<<8 | bit(1)<<7 | bit(2)<<3 | bit(1)<<2 | bit(2)<<1 | bit(1)
+			c = 44
+		case 4:
+			b = bit(3)<<8 | bit(2)<<7 | bit(1)<<6 | bit(3)<<2 | bit(2)<<1 | bit(1)
+			c = 22
+		case 5:
+			b = bit(4)<<8 | bit(3)<<7 | bit(2)<<6 | bit(1)<<5 | bit(4)
+			c = 11
+		case 6:
+			b = bit(5)<<8 | bit(4)<<7 | bit(3)<<6 | bit(2)<<5 | bit(1)<<4 | bit(5)
+			c = 5
+		}
+	} else {
+		switch bits {
+		case 1:
+			c = 113
+		case 2:
+			b = bit(1)<<8 | bit(1)<<3 | bit(1)<<2
+			c = 54
+		case 3:
+			b = bit(2)<<8 | bit(1)<<7 | bit(2)<<2 | bit(1)<<1 | bit(2)
+			c = 26
+		case 4:
+			b = bit(3)<<8 | bit(2)<<7 | bit(1)<<6 | bit(3)<<1 | bit(2)
+			c = 13
+		case 5:
+			b = bit(4)<<8 | bit(3)<<7 | bit(2)<<6 | bit(1)<<5 | bit(4)
+			c = 6
+		}
+	}
+
+	d := int32(value >> bits)
+	t := d*c + b
+	t ^= a
+
+	return (a & 0x80) | (t >> 2)
+}
+
+// unquantizeASTCWeight maps a quantized weight to the range [0, 64]
+func unquantizeASTCWeight(value uint32, level int) int32 {
+	encoding := astcRanges[level]
+	var result int32
+
+	switch {
+	case !encoding.Trits && !encoding.Quints:
+		result = replicateBits(value, encoding.Bits, 6)
+	case encoding.Bits == 0:
+		if encoding.Trits {
+			result = []int32{0, 32, 63}[value]
+		} else {
+			result = []int32{0, 16, 32, 47, 63}[value]
+		}
+	default:
+		a := int32(0)
+		if value&1 != 0 {
+			a = 0x7F
+		}
+
+		bit := int32(value>>1) & 1
+
+		var b, c int32
+		if encoding.Trits {
+			switch encoding.Bits {
+			case 1:
+				c = 50
+			case 2:
+				b = bit<<6 | bit<<2 | bit
+				c = 23
+			case 3:
+				bit2 := int32(value>>2) & 1
+				b = bit2<<6 | bit<<5 | bit2<<1 | bit
+				c = 11
+			}
+		} else {
+			switch encoding.Bits {
+			case 1:
+				c = 28
+			case 2:
+				b = bit<<6 | bit<<1
+				c = 13
+			}
+		}
+
+		d := int32(value >> encoding.Bits)
+		t := d*c + b
+		t ^= a
+		result = (a & 0x20) | (t >> 2)
+	}
+
+	if result > 32 {
+		result++
+	}
+
+	return result
+}
+
+type astcBlockMode struct {
+	Width     int
+	Height    int
+	DualPlane bool
+	Level     int
+}
+
+func decodeASTCBlockMode(mode uint32) (*astcBlockMode, bool) {
+	baseLevel := (mode >> 4) & 1
+	h := (mode >> 9) & 1
+	dualPlane := (mode>>10)&1 != 0
+	a := int((mode >> 5) & 3)
+
+	var width, height int
+
+	if mode&3 != 0 {
+		baseLevel |= (mode & 3) << 1
+		b := int((mode >> 7) & 3)
+
+		switch (mode >> 2) & 3 {
+		case 0:
+			width, height = b+4, a+2
+		case 1:
+			width, height = b+8, a+2
+		case 2:
+			width, height = a+2, b+8
+		case 3:
+			b &= 1
+			if mode&0x100 != 0 {
+				width, height = b+2, a+2
+			} else {
+				width, height = a+2, b+6
+			}
+		}
+	} else {
+		baseLevel |= ((mode >> 2) & 3) << 1
+		if (mode>>2)&3 == 0 {
+			return nil, false
+		}
+
+		b := int((mode >> 9) & 3)
+
+		switch (mode >> 7) & 3 {
+		case 0:
+			width, height = 12, a+2
+		case 1:
+			width, height = a+2, 12
+		case 2:
+			width, height = a+6, b+6
+			dualPlane = false
+			h = 0
+		case 3:
+			switch (mode >> 5) & 3 {
+			case 0:
+				width, height = 6, 10
+			case 1:
+				width, height = 10, 6
+			default:
+				return nil, false
+			}
+		}
+	}
+
+	planes := 1
+	if dualPlane {
+		planes = 2
+	}
+
+	count := width * height * planes
+	level := int(baseLevel) - 2 + 6*int(h)
+
+	if count > 64 || level < 0 || level > 11 {
+		return nil, false
+	}
+
+	weightBits := astcSequenceBits(count, level)
+	if weightBits < 24 || weightBits > 96 {
+		return nil, false
+	}
+
+	return &astcBlockMode{
+		Width:     width,
+		Height:    height,
+		DualPlane: dualPlane,
+		Level:     level,
+	}, true
+}
+
+func astcHash52(p uint32) uint32 {
+	p ^= p >> 15
+	p -= p << 17
+	p += p << 7
+	p += p << 4
+	p ^= p >> 5
+	p += p << 16
+	p ^= p >> 7
+	p ^= p >> 3
+	p ^= p << 6
+	p ^= p >> 17
+	return p
+}
+
+func astcSelectPartition(seed int, x int, y int, partitionCount int, smallBlock bool) int {
+	if smallBlock {
+		x <<= 1
+		y <<= 1
+	}
+
+	seed += (partitionCount - 1) * 1024
+	rnum := astcHash52(uint32(seed))
+
+	seeds := [8]int{
+		int(rnum & 0xF),
+		int((rnum >> 4) & 0xF),
+		int((rnum >> 8) & 0xF),
+		int((rnum >> 12) & 0xF),
+		int((rnum >> 16) & 0xF),
+		int((rnum >> 20) & 0xF),
+		int((rnum >> 24) & 0xF),
+		int((rnum >> 28) & 0xF),
+	}
+
+	for i := range seeds {
+		seeds[i] *= seeds[i]
+	}
+
+	var sh1, sh2 uint
+	if seed&1 != 0 {
+		sh1 = 5
+		if seed&2 != 0 {
+			sh1 = 4
+		}
+		sh2 = 5
+		if partitionCount == 3 {
+			sh2 = 6
+		}
+	} else {
+		sh1 = 5
+		if partitionCount == 3 {
+			sh1 = 6
+		}
+		sh2 = 5
+		if seed&2 != 0 {
+			sh2 = 4
+		}
+	}
+
+	for i := range seeds {
+		if i%2 == 0 {
+			seeds[i] >>= sh1
+		} else {
+			seeds[i] >>= sh2
+		}
+	}
+
+	// The z seeds are irrelevant for 2D blocks
+	a := (seeds[0]*x + seeds[1]*y + int(rnum>>14)) & 0x3F
+	b := (seeds[2]*x + seeds[3]*y + int(rnum>>10)) & 0x3F
+	c := (seeds[4]*x + seeds[5]*y + int(rnum>>6)) & 0x3F
+	d := (seeds[6]*x + seeds[7]*y + int(rnum>>2)) & 0x3F
+
+	if partitionCount < 4 {
+		d = 0
+	}
+
+	if partitionCount < 3 {
+		c = 0
+	}
+
+	switch {
+	case a >= b && a >= c && a >= d:
+		return 0
+	case b >= c && b >= d:
+		return 1
+	case c >= d:
+		return 2
+	}
+
+	return 3
+}
+
+func clampByte(value int32) int32 {
+	if value < 0 {
+		return 0
+	}
+
+	if value > 255 {
+		return 255
+	}
+
+	return value
+}
+
+// bitTransferSigned moves the top bit of b into a, returning a as a signed 6 bit offset
+func bitTransferSigned(a int32, b int32) (int32, int32) {
+	b >>= 1
+	b |= a & 0x80
+	a >>= 1
+	a &= 0x3F
+
+	if a&0x20 != 0 {
+		a -= 0x40
+	}
+
+	return a, b
+}
+
+func blueContract(r int32, g int32, b int32, a int32) [4]int32 {
+	return [4]int32{(r + b) >> 1, (g + b) >> 1, b, a}
+}
+
+// decodeASTCEndpoints returns the LDR endpoints of the color endpoint mode, false for HDR modes
+func decodeASTCEndpoints(mode uint32, v []int32) ([4]int32, [4]int32, bool) {
+	switch mode {
+	case 0:
+		return [4]int32{v[0], v[0], v[0], 255}, [4]int32{v[1], v[1], v[1], 255}, true
+	case 1:
+		l0 := (v[0] >> 2) | (v[1] & 0xC0)
+		l1 := l0 + (v[1] & 0x3F)
+		if l1 > 255 {
+			l1 = 255
+		}
+		return [4]int32{l0, l0, l0, 255}, [4]int32{l1, l1, l1, 255}, true
+	case 4:
+		return [4]int32{v[0], v[0], v[0], v[2]}, [4]int32{v[1], v[1], v[1], v[3]}, true
+	case 5:
+		v1, v0 := bitTransferSigned(v[1], v[0])
+		v3, v2 := bitTransferSigned(v[3], v[2])
+		l1 := clampByte(v0 + v1)
+		return [4]int32{v0, v0, v0, v2}, [4]int32{l1, l1, l1, clampByte(v2 + v3)}, true
+	case 6:
+		return [4]int32{(v[0] * v[3]) >> 8, (v[1] * v[3]) >> 8, (v[2] * v[3]) >> 8, 255}, [4]int32{v[0], v[1], v[2], 255}, true
+	case 8, 12:
+		a0, a1 := int32(255), int32(255)
+		if mode == 12 {
+			a0, a1 = v[6], v[7]
+		}
+
+		if v[1]+v[3]+v[5] >= v[0]+v[2]+v[4] {
+			return [4]int32{v[0], v[2], v[4], a0}, [4]int32{v[1], v[3], v[5], a1}, true
+		}
+
+		return blueContract(v[1], v[3], v[5], a1), blueContract(v[0], v[2], v[4], a0), true
+	case 9, 13:
+		v1, v0 := bitTransferSigned(v[1], v[0])
+		v3, v2 := bitTransferSigned(v[3], v[2])
+		v5, v4 := bitTransferSigned(v[5], v[4])
+
+		a0, a1 := int32(255), int32(255)
+		if mode == 13 {
+			v7, v6 := bitTransferSigned(v[7], v[6])
+			a0, a1 = v6, clampByte(v6+v7)
+		}
+
+		if v1+v3+v5 >= 0 {
+			return [4]int32{v0, v2, v4, a0}, [4]int32{clampByte(v0 + v1), clampByte(v2 + v3), clampByte(v4 + v5), a1}, true
+		}
+
+		e0 := blueContract(clampByte(v0+v1), clampByte(v2+v3), clampByte(v4+v5), a1)
+		e1 := blueContract(v0, v2, v4, a0)
+		return e0, e1, true
+	case 10:
+		return [4]int32{(v[0] * v[3]) >> 8, (v[1] * v[3]) >> 8, (v[2] * v[3]) >> 8, v[4]}, [4]int32{v[0], v[1], v[2], v[5]}, true
+	}
+
+	return [4]int32{}, [4]int32{}, false
+}
+
+// DecodeASTC decodes LDR ASTC blocks, HDR and invalid blocks are filled with magenta
+func DecodeASTC(data []byte, width int32, height int32, blockWidth int32, blockHeight int32) image.Image {
+	return decodeBlocks(data, width, height, blockWidth, blockHeight, 16, func(block []byte, pixels []color.NRGBA) {
+		bits := &astcBits{}
+		copy(bits[:], block)
+
+		if !decodeASTCBlock(bits, int(blockWidth), int(blockHeight), pixels) {
+			for i := range pixels {
+				pixels[i] = astcErrorColor
+			}
+		}
+	})
+}
+
+func decodeASTCBlock(bits *astcBits, blockWidth int, blockHeight int, pixels []color.NRGBA) bool {
+	blockMode := bits.read(0, 11)
+
+	// Void extent blocks have a single constant color
+	if blockMode&0x1FF == 0x1FC {
+		if blockMode&0x200 != 0 {
+			return false
+		}
+
+		constant := color.NRGBA{
+			R: uint8(bits.read(64, 16) >> 8),
+			G: uint8(bits.read(80, 16) >> 8),
+			B: uint8(bits.read(96, 16) >> 8),
+			A: uint8(bits.read(112, 16) >> 8),
+		}
+
+		for i := range pixels {
+			pixels[i] = constant
+		}
+
+		return true
+	}
+
+	mode, ok := decodeASTCBlockMode(blockMode)
+	if !ok || mode.Width > blockWidth || mode.Height > blockHeight {
+		return false
+	}
+
+	partitionCount := int(bits.read(11, 2)) + 1
+	if partitionCount == 4 && mode.DualPlane {
+		return false
+	}
+
+	planes := 1
+	if mode.DualPlane {
+		planes = 2
+	}
+
+	weightCount := mode.Width * mode.Height * planes
+	weightBits := astcSequenceBits(weightCount, mode.Level)
+	belowWeights := 128 - weightBits
+
+	endpointModes := make([]uint32, partitionCount)
+	partitionSeed := 0
+	colorStart := 17
+
+	if partitionCount == 1 {
+		endpointModes[0] = bits.read(13, 4)
+	} else {
+		partitionSeed = int(bits.read(13, 10))
+		colorStart = 29
+
+		encoded := bits.read(23, 6)
+		if encoded&3 == 0 {
+			for i := range endpointModes {
+				endpointModes[i] = encoded >> 2
+			}
+		} else {
+			extraBits := partitionCount*3 - 4
+			belowWeights -= extraBits
+			encoded |= bits.read(belowWeights, extraBits) << 6
+
+			baseClass := encoded&3 - 1
+			encoded >>= 2
+
+			for i := range endpointModes {
+				class := (encoded >> i) & 1
+				m := (encoded >> (partitionCount + 2*i)) & 3
+				endpointModes[i] = (baseClass+class)<<2 | m
+			}
+		}
+	}
+
+	planeComponent := -1
+	if mode.DualPlane {
+		belowWeights -= 2
+		planeComponent = int(bits.read(belowWeights, 2))
+	}
+
+	valueCount := 0
+	for _, endpointMode := range endpointModes {
+		valueCount += int(endpointMode>>2+1) * 2
+	}
+
+	if valueCount > 18 {
+		return false
+	}
+
+	colorBits := belowWeights - colorStart
+	colorLevel := -1
+	for level := len(astcRanges) - 1; level >= 0; level-- {
+		if astcSequenceBits(valueCount, level) <= colorBits {
+			colorLevel = level
+			break
+		}
+	}
+
+	if colorLevel < 4 {
+		return false
+	}
+
+	colorValues := decodeASTCSequence(bits, colorStart, valueCount, colorLevel)
+
+	endpoints := make([][2][4]int32, partitionCount)
+	offset := 0
+	for i, endpointMode := range endpointModes {
+		count := int(endpointMode>>2+1) * 2
+		values := make([]int32, count)
+
+		for j := range values {
+			values[j] = unquantizeASTCColor(colorValues[offset+j], colorLevel)
+		}
+		offset += count
+
+		e0, e1, ok := decodeASTCEndpoints(endpointMode, values)
+		if !ok {
+			return false
+		}
+
+		endpoints[i] = [2][4]int32{e0, e1}
+	}
+
+	weightValues := decodeASTCSequence(bits.reversed(), 0, weightCount, mode.Level)
+	weights := make([]int32, weightCount)
+	for i, value := range weightValues {
+		weights[i] = unquantizeASTCWeight(value, mode.Level)
+	}
+
+	smallBlock := blockWidth*blockHeight < 31
+
+	ds := (1024 + blockWidth/2) / (blockWidth - 1)
+	dt := (1024 + blockHeight/2) / (blockHeight - 1)
+
+	gridWeight := func(index int, plane int) int32 {
+		if index >= mode.Width*mode.Height {
+			return 0
+		}
+
+		return weights[index*planes+plane]
+	}
+
+	for y := 0; y < blockHeight; y++ {
+		for x := 0; x < blockWidth; x++ {
+			gs := (ds*x*(mode.Width-1) + 32) >> 6
+			gt := (dt*y*(mode.Height-1) + 32) >> 6
+			js := gs >> 4
+			fs := int32(gs & 0xF)
+			jt := gt >> 4
+			ft := int32(gt & 0xF)
+
+			w11 := (fs*ft + 8) >> 4
+			w10 := ft - w11
+			w01 := fs - w11
+			w00 := 16 - fs - ft + w11
+
+			index := js + jt*mode.Width
+
+			var texelWeights [2]int32
+			for plane := 0; plane < planes; plane++ {
+				texelWeights[plane] = (gridWeight(index, plane)*w00 +
+					gridWeight(index+1, plane)*w01 +
+					gridWeight(index+mode.Width, plane)*w10 +
+					gridWeight(index+mode.Width+1, plane)*w11 + 8) >> 4
+			}
+
+			partition := 0
+			if partitionCount > 1 {
+				partition = astcSelectPartition(partitionSeed, x, y, partitionCount, smallBlock)
+			}
+
+			e0 := endpoints[partition][0]
+			e1 := endpoints[partition][1]
+
+			var channels [4]uint8
+			for channel := 0; channel < 4; channel++ {
+				weight := texelWeights[0]
+				if channel == planeComponent {
+					weight = texelWeights[1]
+				}
+
+				c0 := e0[channel]<<8 | e0[channel]
+				c1 := e1[channel]<<8 | e1[channel]
+				channels[channel] = uint8(((c0*(64-weight) + c1*weight + 32) >> 6) >> 8)
+			}
+
+			pixels[y*blockWidth+x] = color.NRGBA{R: channels[0], G: channels[1], B: channels[2], A: channels[3]}
+		}
+	}
+
+	return true
+}
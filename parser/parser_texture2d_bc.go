@@ -0,0 +1,570 @@
+package parser
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/x448/float16"
+)
+
+// blockBits reads the bits of a 128 bit block starting from the least significant bit
+type blockBits struct {
+	lo  uint64
+	hi  uint64
+	pos uint
+}
+
+func newBlockBits(block []byte) *blockBits {
+	return &blockBits{
+		lo: binary.LittleEndian.Uint64(block[0:8]),
+		hi: binary.LittleEndian.Uint64(block[8:16]),
+	}
+}
+
+func (bits *blockBits) bit(position uint) uint32 {
+	if position < 64 {
+		return uint32(bits.lo>>position) & 1
+	}
+
+	return uint32(bits.hi>>(position-64)) & 1
+}
+
+func (bits *blockBits) read(count uint) uint32 {
+	value := uint32(0)
+
+	for i := uint(0); i < count; i++ {
+		value |= bits.bit(bits.pos+i) << i
+	}
+
+	bits.pos += count
+
+	return value
+}
+
+// decodeBlocks decodes a block compressed image, clipping blocks at the right and bottom edges
+func decodeBlocks(data []byte, width int32, height int32, blockWidth int32, blockHeight int32, blockSize int32, decode func(block []byte, pixels []color.NRGBA)) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, int(width), int(height)))
+
+	blocksX := (width + blockWidth - 1) / blockWidth
+	blocksY := (height + blockHeight - 1) / blockHeight
+	pixels := make([]color.NRGBA, blockWidth*blockHeight)
+
+	for by := int32(0); by < blocksY; by++ {
+		for bx := int32(0); bx < blocksX; bx++ {
+			offset := (by*blocksX + bx) * blockSize
+			if offset+blockSize > int32(len(data)) {
+				return img
+			}
+
+			decode(data[offset:offset+blockSize], pixels)
+
+			for y := int32(0); y < blockHeight && by*blockHeight+y < height; y++ {
+				for x := int32(0); x < blockWidth && bx*blockWidth+x < width; x++ {
+					img.SetNRGBA(int(bx*blockWidth+x), int(by*blockHeight+y), pixels[y*blockWidth+x])
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+func DecodeBC4(data []byte, width int32, height int32) image.Image {
+	values := make([]uint8, 16)
+
+	return decodeBlocks(data, width, height, 4, 4, 8, func(block []byte, pixels []color.NRGBA) {
+		decodeBC4Block(block, values)
+
+		for i, value := range values {
+			pixels[i] = color.NRGBA{R: value, G: value, B: value, A: 255}
+		}
+	})
+}
+
+// DecodeBC5 decodes a two channel normal map, reconstructing the Z channel into blue
+func DecodeBC5(data []byte, width int32, height int32) image.Image {
+	red := make([]uint8, 16)
+	green := make([]uint8, 16)
+
+	return decodeBlocks(data, width, height, 4, 4, 16, func(block []byte, pixels []color.NRGBA) {
+		decodeBC4Block(block[0:8], red)
+		decodeBC4Block(block[8:16], green)
+
+		for i := range pixels {
+			x := float64(red[i])/255*2 - 1
+			y := float64(green[i])/255*2 - 1
+			z := math.Sqrt(math.Max(0, 1-x*x-y*y))
+
+			pixels[i] = color.NRGBA{R: red[i], G: green[i], B: uint8(math.Round((z + 1) / 2 * 255)), A: 255}
+		}
+	})
+}
+
+func decodeBC4Block(block []byte, values []uint8) {
+	palette := [8]int32{int32(block[0]), int32(block[1])}
+
+	if palette[0] > palette[1] {
+		for i := int32(1); i < 7; i++ {
+			palette[i+1] = ((7-i)*palette[0] + i*palette[1]) / 7
+		}
+	} else {
+		for i := int32(1); i < 5; i++ {
+			palette[i+1] = ((5-i)*palette[0] + i*palette[1]) / 5
+		}
+
+		palette[6] = 0
+		palette[7] = 255
+	}
+
+	indices := uint64(0)
+	for i := 0; i < 6; i++ {
+		indices |= uint64(block[2+i]) << (8 * i)
+	}
+
+	for i := range values {
+		values[i] = uint8(palette[(indices>>(3*i))&7])
+	}
+}
+
+var bcWeights2 = []int32{0, 21, 43, 64}
+var bcWeights3 = []int32{0, 9, 18, 27, 37, 46, 55, 64}
+var bcWeights4 = []int32{0, 4, 9, 13, 17, 21, 26, 30, 34, 38, 43, 47, 51, 55, 60, 64}
+
+func bcInterpolate(e0 int32, e1 int32, weight int32) int32 {
+	return (e0*(64-weight) + e1*weight + 32) >> 6
+}
+
+// https://docs.microsoft.com/en-us/windows/win32/direct3d11/bc7-format-mode-reference
+var bc7Partitions2 = []uint16{
+	0xcccc, 0x8888, 0xeeee, 0xecc8, 0xc880, 0xfeec, 0xfec8, 0xec80,
+	0xc800, 0xffec, 0xfe80, 0xe800, 0xffe8, 0xff00, 0xfff0, 0xf000,
+	0xf710, 0x008e, 0x7100, 0x08ce, 0x008c, 0x7310, 0x3100, 0x8cce,
+	0x088c, 0x3110, 0x6666, 0x366c, 0x17e8, 0x0ff0, 0x718e, 0x399c,
+	0xaaaa, 0xf0f0, 0x5a5a, 0x33cc, 0x3c3c, 0x55aa, 0x9696, 0xa55a,
+	0x73ce, 0x13c8, 0x324c, 0x3bdc, 0x6996, 0xc33c, 0x9966, 0x0660,
+	0x0272, 0x04e4, 0x4e40, 0x2720, 0xc936, 0x936c, 0x39c6, 0x639c,
+	0x9336, 0x9cc6, 0x817e, 0xe718, 0xccf0, 0x0fcc, 0x7744, 0xee22,
+}
+
+var bc7Partitions3 = []string{
+	"0011001102210222", "0001001122112221", "0000200122112211", "0222002200110111",
+	"0000000011221122", "0011001100220022", "0022002211111111", "0011001122112211",
+	"0000000011112222", "0000111111112222", "0000111122222222", "0012001200120012",
+	"0112011201120112", "0122012201220122", "0011011211221222", "0011200122002220",
+	"0001001101121122", "0111001120012200", "0000112211221122", "0022002200221111",
+	"0111011102220222", "0001000122212221", "0000001101220122", "0000110022102210",
+	"0122012200110000", "0012001211222222", "0110122112210110", "0000011012211221",
+	"0022110211020022", "0110011020022222", "0011012201220011", "0000200022112221",
+	"0000000211221222", "0222002200120011", "0011001200220222", "0120012001200120",
+	"0000111122220000", "0120120120120120", "0120201212010120", "0011220011220011",
+	"0011112222000011", "0101010122222222", "0000000021212121", "0022112200221122",
+	"0022001100220011", "0220122102201221", "0101222222220101", "0000212121212121",
+	"0101010101012222", "0222011102220111", "0002111200021112", "0000211221122112",
+	"0222011101110222", "0002111211120002", "0110011001102222", "0000000021122112",
+	"0110011022222222", "0022001100110022", "0022112211220022", "0000000000002112",
+	"0002000100020001", "0222122202221222", "0101222222222222", "0111201122012220",
+}
+
+var bc7Anchors2 = []int{
+	15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15,
+	15, 2, 8, 2, 2, 8, 8, 15, 2, 8, 2, 2, 8, 8, 2, 2,
+	15, 15, 6, 8, 2, 8, 15, 15, 2, 8, 2, 2, 2, 15, 15, 6,
+	6, 2, 6, 8, 15, 15, 2, 2, 15, 15, 15, 15, 15, 2, 2, 15,
+}
+
+var bc7Anchors3Second = []int{
+	3, 3, 15, 15, 8, 3, 15, 15, 8, 8, 6, 6, 6, 5, 3, 3,
+	3, 3, 8, 15, 3, 3, 6, 10, 5, 8, 8, 6, 8, 5, 15, 15,
+	8, 15, 3, 5, 6, 10, 8, 15, 15, 3, 15, 5, 15, 15, 15, 15,
+	3, 15, 5, 5, 5, 8, 5, 10, 5, 10, 8, 13, 15, 12, 3, 3,
+}
+
+var bc7Anchors3Third = []int{
+	15, 8, 8, 3, 15, 15, 3, 8, 15, 15, 15, 15, 15, 15, 15, 8,
+	15, 8, 15, 3, 15, 8, 15, 8, 3, 15, 6, 10, 15, 15, 10, 8,
+	15, 3, 15, 10, 10, 8, 9, 10, 6, 15, 8, 15, 3, 6, 6, 8,
+	15, 3, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 3, 15, 15, 8,
+}
+
+// bc7Subset returns the subset of the pixel in the partition
+func bc7Subset(subsets int, partition int, pixel int) int {
+	switch subsets {
+	case 2:
+		return int(bc7Partitions2[partition]>>pixel) & 1
+	case 3:
+		return int(bc7Partitions3[partition][pixel] - '0')
+	}
+
+	return 0
+}
+
+// bc7IsAnchor reports whether the pixel is the anchor of its subset, stored with one less index bit
+func bc7IsAnchor(subsets int, partition int, pixel int) bool {
+	if pixel == 0 {
+		return true
+	}
+
+	switch subsets {
+	case 2:
+		return pixel == bc7Anchors2[partition]
+	case 3:
+		return pixel == bc7Anchors3Second[partition] || pixel == bc7Anchors3Third[partition]
+	}
+
+	return false
+}
+
+type bc7Mode struct {
+	Subsets        int
+	PartitionBits  uint
+	RotationBits   uint
+	IndexSelection uint
+	ColorBits      uint
+	AlphaBits      uint
+	EndpointPBits  bool
+	SharedPBits    bool
+	IndexBits      uint
+	Index2Bits     uint
+}
+
+var bc7Modes = []bc7Mode{
+	{Subsets: 3, PartitionBits: 4, ColorBits: 4, EndpointPBits: true, IndexBits: 3},
+	{Subsets: 2, PartitionBits: 6, ColorBits: 6, SharedPBits: true, IndexBits: 3},
+	{Subsets: 3, PartitionBits: 6, ColorBits: 5, IndexBits: 2},
+	{Subsets: 2, PartitionBits: 6, ColorBits: 7, EndpointPBits: true, IndexBits: 2},
+	{Subsets: 1, RotationBits: 2, IndexSelection: 1, ColorBits: 5, AlphaBits: 6, IndexBits: 2, Index2Bits: 3},
+	{Subsets: 1, RotationBits: 2, ColorBits: 7, AlphaBits: 8, IndexBits: 2, Index2Bits: 2},
+	{Subsets: 1, ColorBits: 7, AlphaBits: 7, EndpointPBits: true, IndexBits: 4},
+	{Subsets: 2, PartitionBits: 6, ColorBits: 5, AlphaBits: 5, EndpointPBits: true, IndexBits: 2},
+}
+
+func DecodeBC7(data []byte, width int32, height int32) image.Image {
+	return decodeBlocks(data, width, height, 4, 4, 16, decodeBC7Block)
+}
+
+func bcWeights(bits uint) []int32 {
+	switch bits {
+	case 2:
+		return bcWeights2
+	case 3:
+		return bcWeights3
+	}
+
+	return bcWeights4
+}
+
+// expandBits replicates the high bits of a value into the low bits of an 8 bit value
+func expandBits(value uint32, bits uint) int32 {
+	value <<= 8 - bits
+	return int32(value | value>>bits)
+}
+
+func decodeBC7Block(block []byte, pixels []color.NRGBA) {
+	bits := newBlockBits(block)
+
+	modeIndex := 0
+	for modeIndex < 8 && bits.read(1) == 0 {
+		modeIndex++
+	}
+
+	if modeIndex == 8 {
+		for i := range pixels {
+			pixels[i] = color.NRGBA{}
+		}
+		return
+	}
+
+	mode := bc7Modes[modeIndex]
+
+	partition := int(bits.read(mode.PartitionBits))
+	rotation := bits.read(mode.RotationBits)
+	indexSelection := bits.read(mode.IndexSelection)
+
+	// endpoints[subset*2+endpoint][channel]
+	endpoints := make([][4]uint32, mode.Subsets*2)
+
+	for channel := 0; channel < 3; channel++ {
+		for i := range endpoints {
+			endpoints[i][channel] = bits.read(mode.ColorBits)
+		}
+	}
+
+	if mode.AlphaBits > 0 {
+		for i := range endpoints {
+			endpoints[i][3] = bits.read(mode.AlphaBits)
+		}
+	}
+
+	colorBits := mode.ColorBits
+	alphaBits := mode.AlphaBits
+
+	if mode.EndpointPBits || mode.SharedPBits {
+		colorBits++
+		if alphaBits > 0 {
+			alphaBits++
+		}
+
+		pBits := make([]uint32, len(endpoints))
+		if mode.EndpointPBits {
+			for i := range pBits {
+				pBits[i] = bits.read(1)
+			}
+		} else {
+			for subset := 0; subset < mode.Subsets; subset++ {
+				pBit := bits.read(1)
+				pBits[subset*2] = pBit
+				pBits[subset*2+1] = pBit
+			}
+		}
+
+		for i := range endpoints {
+			for channel := 0; channel < 4; channel++ {
+				endpoints[i][channel] = endpoints[i][channel]<<1 | pBits[i]
+			}
+		}
+	}
+
+	expanded := make([][4]int32, len(endpoints))
+	for i := range endpoints {
+		for channel := 0; channel < 3; channel++ {
+			expanded[i][channel] = expandBits(endpoints[i][channel], colorBits)
+		}
+
+		if alphaBits > 0 {
+			expanded[i][3] = expandBits(endpoints[i][3], alphaBits)
+		} else {
+			expanded[i][3] = 255
+		}
+	}
+
+	indices := make([]uint32, 16)
+	for i := range indices {
+		count := mode.IndexBits
+		if bc7IsAnchor(mode.Subsets, partition, i) {
+			count--
+		}
+		indices[i] = bits.read(count)
+	}
+
+	var indices2 []uint32
+	if mode.Index2Bits > 0 {
+		indices2 = make([]uint32, 16)
+		for i := range indices2 {
+			count := mode.Index2Bits
+			if i == 0 {
+				count--
+			}
+			indices2[i] = bits.read(count)
+		}
+	}
+
+	for i := range pixels {
+		subset := bc7Subset(mode.Subsets, partition, i)
+		e0 := expanded[subset*2]
+		e1 := expanded[subset*2+1]
+
+		colorWeight := bcWeights(mode.IndexBits)[indices[i]]
+		alphaWeight := colorWeight
+
+		if indices2 != nil {
+			alphaWeight = bcWeights(mode.Index2Bits)[indices2[i]]
+
+			if indexSelection == 1 {
+				colorWeight, alphaWeight = alphaWeight, colorWeight
+			}
+		}
+
+		pixel := [4]int32{
+			bcInterpolate(e0[0], e1[0], colorWeight),
+			bcInterpolate(e0[1], e1[1], colorWeight),
+			bcInterpolate(e0[2], e1[2], colorWeight),
+			bcInterpolate(e0[3], e1[3], alphaWeight),
+		}
+
+		if rotation > 0 {
+			pixel[3], pixel[rotation-1] = pixel[rotation-1], pixel[3]
+		}
+
+		pixels[i] = color.NRGBA{R: uint8(pixel[0]), G: uint8(pixel[1]), B: uint8(pixel[2]), A: uint8(pixel[3])}
+	}
+}
+
+type bc6hBit struct {
+	Field int
+	Bit   uint
+}
+
+type bc6hMode struct {
+	Transformed  bool
+	Partitioned  bool
+	EndpointBits uint
+	DeltaBits    [3]uint
+	Layout       []bc6hBit
+}
+
+// https://docs.microsoft.com/en-us/windows/win32/direct3d11/bc6h-format
+// Fields are listed in the order they are stored after the mode bits
+var bc6hModes = map[uint32]*bc6hMode{
+	0x00: newBC6HMode(true, true, 10, [3]uint{5, 5, 5}, "g2[4] b2[4] b3[4] r0[9:0] g0[9:0] b0[9:0] r1[4:0] g3[4] g2[3:0] g1[4:0] b3[0] g3[3:0] b1[4:0] b3[1] b2[3:0] r2[4:0] b3[2] r3[4:0] b3[3]"),
+	0x01: newBC6HMode(true, true, 7, [3]uint{6, 6, 6}, "g2[5] g3[4] g3[5] r0[6:0] b3[0] b3[1] b2[4] g0[6:0] b2[5] b3[2] g2[4] b0[6:0] b3[3] b3[5] b3[4] r1[5:0] g2[3:0] g1[5:0] g3[3:0] b1[5:0] b2[3:0] r2[5:0] r3[5:0]"),
+	0x02: newBC6HMode(true, true, 11, [3]uint{5, 4, 4}, "r0[9:0] g0[9:0] b0[9:0] r1[4:0] r0[10] g2[3:0] g1[3:0] g0[10] b3[0] g3[3:0] b1[3:0] b0[10] b3[1] b2[3:0] r2[4:0] b3[2] r3[4:0] b3[3]"),
+	0x06: newBC6HMode(true, true, 11, [3]uint{4, 5, 4}, "r0[9:0] g0[9:0] b0[9:0] r1[3:0] r0[10] g3[4] g2[3:0] g1[4:0] g0[10] g3[3:0] b1[3:0] b0[10] b3[1] b2[3:0] r2[3:0] b3[0] b3[2] r3[3:0] g2[4] b3[3]"),
+	0x0a: newBC6HMode(true, true, 11, [3]uint{4, 4, 5}, "r0[9:0] g0[9:0] b0[9:0] r1[3:0] r0[10] b2[4] g2[3:0] g1[3:0] g0[10] b3[0] g3[3:0] b1[4:0] b0[10] b2[3:0] r2[3:0] b3[1] b3[2] r3[3:0] b3[4] b3[3]"),
+	0x0e: newBC6HMode(true, true, 9, [3]uint{5, 5, 5}, "r0[8:0] b2[4] g0[8:0] g2[4] b0[8:0] b3[4] r1[4:0] g3[4] g2[3:0] g1[4:0] b3[0] g3[3:0] b1[4:0] b3[1] b2[3:0] r2[4:0] b3[2] r3[4:0] b3[3]"),
+	0x12: newBC6HMode(true, true, 8, [3]uint{6, 5, 5}, "r0[7:0] g3[4] b2[4] g0[7:0] b3[2] g2[4] b0[7:0] b3[3] b3[4] r1[5:0] g2[3:0] g1[4:0] b3[0] g3[3:0] b1[4:0] b3[1] b2[3:0] r2[5:0] r3[5:0]"),
+	0x16: newBC6HMode(true, true, 8, [3]uint{5, 6, 5}, "r0[7:0] b3[0] b2[4] g0[7:0] g2[5] g2[4] b0[7:0] g3[5] b3[4] r1[4:0] g3[4] g2[3:0] g1[5:0] g3[3:0] b1[4:0] b3[1] b2[3:0] r2[4:0] b3[2] r3[4:0] b3[3]"),
+	0x1a: newBC6HMode(true, true, 8, [3]uint{5, 5, 6}, "r0[7:0] b3[1] b2[4] g0[7:0] b2[5] g2[4] b0[7:0] b3[5] b3[4] r1[4:0] g3[4] g2[3:0] g1[4:0] b3[0] g3[3:0] b1[5:0] b2[3:0] r2[4:0] b3[2] r3[4:0] b3[3]"),
+	0x1e: newBC6HMode(false, true, 6, [3]uint{6, 6, 6}, "r0[5:0] g3[4] b3[0] b3[1] b2[4] g0[5:0] g2[5] b2[5] b3[2] g2[4] b0[5:0] g3[5] b3[3] b3[5] b3[4] r1[5:0] g2[3:0] g1[5:0] g3[3:0] b1[5:0] b2[3:0] r2[5:0] r3[5:0]"),
+	0x03: newBC6HMode(false, false, 10, [3]uint{10, 10, 10}, "r0[9:0] g0[9:0] b0[9:0] r1[9:0] g1[9:0] b1[9:0]"),
+	0x07: newBC6HMode(true, false, 11, [3]uint{9, 9, 9}, "r0[9:0] g0[9:0] b0[9:0] r1[8:0] r0[10] g1[8:0] g0[10] b1[8:0] b0[10]"),
+	0x0b: newBC6HMode(true, false, 12, [3]uint{8, 8, 8}, "r0[9:0] g0[9:0] b0[9:0] r1[7:0] r0[11] r0[10] g1[7:0] g0[11] g0[10] b1[7:0] b0[11] b0[10]"),
+	0x0f: newBC6HMode(true, false, 16, [3]uint{4, 4, 4}, "r0[9:0] g0[9:0] b0[9:0] r1[3:0] r0[15] r0[14] r0[13] r0[12] r0[11] r0[10] g1[3:0] g0[15] g0[14] g0[13] g0[12] g0[11] g0[10] b1[3:0] b0[15] b0[14] b0[13] b0[12] b0[11] b0[10]"),
+}
+
+var bc6hFields = map[string]int{
+	"r0": 0, "g0": 1, "b0": 2,
+	"r1": 3, "g1": 4, "b1": 5,
+	"r2": 6, "g2": 7, "b2": 8,
+	"r3": 9, "g3": 10, "b3": 11,
+}
+
+func newBC6HMode(transformed bool, partitioned bool, endpointBits uint, deltaBits [3]uint, layout string) *bc6hMode {
+	mode := &bc6hMode{
+		Transformed:  transformed,
+		Partitioned:  partitioned,
+		EndpointBits: endpointBits,
+		DeltaBits:    deltaBits,
+	}
+
+	for _, token := range strings.Fields(layout) {
+		field := bc6hFields[token[:2]]
+		bitRange := strings.Split(strings.Trim(token[2:], "[]"), ":")
+
+		high, _ := strconv.Atoi(bitRange[0])
+		low := high
+		if len(bitRange) > 1 {
+			low, _ = strconv.Atoi(bitRange[1])
+		}
+
+		for bit := low; bit <= high; bit++ {
+			mode.Layout = append(mode.Layout, bc6hBit{Field: field, Bit: uint(bit)})
+		}
+	}
+
+	return mode
+}
+
+// DecodeBC6H decodes unsigned BC6H (the only variant used by UE4), clamping the HDR values
+func DecodeBC6H(data []byte, width int32, height int32) image.Image {
+	return decodeBlocks(data, width, height, 4, 4, 16, decodeBC6HBlock)
+}
+
+func signExtend(value int32, bits uint) int32 {
+	shift := 32 - bits
+	return (value << shift) >> shift
+}
+
+func bc6hUnquantize(value int32, bits uint) int32 {
+	if bits >= 15 {
+		return value
+	}
+
+	if value == 0 {
+		return 0
+	}
+
+	if value == (1<<bits)-1 {
+		return 0xFFFF
+	}
+
+	return ((value << 16) + 0x8000) >> bits
+}
+
+func decodeBC6HBlock(block []byte, pixels []color.NRGBA) {
+	bits := newBlockBits(block)
+
+	modeBits := bits.read(2)
+	if modeBits > 1 {
+		modeBits |= bits.read(3) << 2
+	}
+
+	mode, ok := bc6hModes[modeBits]
+	if !ok {
+		for i := range pixels {
+			pixels[i] = color.NRGBA{A: 255}
+		}
+		return
+	}
+
+	var values [12]int32
+	for _, layoutBit := range mode.Layout {
+		values[layoutBit.Field] |= int32(bits.read(1)) << layoutBit.Bit
+	}
+
+	endpointCount := 2
+	partition := 0
+	if mode.Partitioned {
+		endpointCount = 4
+		partition = int(bits.read(5))
+	}
+
+	mask := int32(1)<<mode.EndpointBits - 1
+
+	if mode.Transformed {
+		for i := 3; i < endpointCount*3; i++ {
+			channel := i % 3
+			values[i] = (values[channel] + signExtend(values[i], mode.DeltaBits[channel])) & mask
+		}
+	}
+
+	for i := 0; i < endpointCount*3; i++ {
+		values[i] = bc6hUnquantize(values[i], mode.EndpointBits)
+	}
+
+	indexBits := uint(4)
+	if mode.Partitioned {
+		indexBits = 3
+	}
+
+	for i := range pixels {
+		count := indexBits
+		subset := 0
+
+		if mode.Partitioned {
+			subset = bc7Subset(2, partition, i)
+			if i == 0 || i == bc7Anchors2[partition] {
+				count--
+			}
+		} else if i == 0 {
+			count--
+		}
+
+		weight := bcWeights(indexBits)[bits.read(count)]
+
+		var channels [3]uint8
+		for channel := 0; channel < 3; channel++ {
+			interpolated := bcInterpolate(values[subset*6+channel], values[subset*6+3+channel], weight)
+			half := float16.Float16(uint16((interpolated * 31) >> 6))
+			channels[channel] = clampUnitFloat(half.Float32())
+		}
+
+		pixels[i] = color.NRGBA{R: channels[0], G: channels[1], B: channels[2], A: 255}
+	}
+}
+
+// clampUnitFloat converts a float in the range [0, 1] to a byte, clamping values outside of it
+func clampUnitFloat(value float32) uint8 {
+	if value <= 0 || value != value {
+		return 0
+	}
+
+	if value >= 1 {
+		return 255
+	}
+
+	return uint8(value*255 + 0.5)
+}
@@ -0,0 +1,277 @@
+package parser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"strings"
+)
+
+const (
+	ddsdCaps        = 0x1
+	ddsdHeight      = 0x2
+	ddsdWidth       = 0x4
+	ddsdPitch       = 0x8
+	ddsdPixelFormat = 0x1000
+	ddsdMipMapCount = 0x20000
+	ddsdLinearSize  = 0x80000
+
+	ddpfAlphaPixels = 0x1
+	ddpfFourCC      = 0x4
+	ddpfRGB         = 0x40
+	ddpfLuminance   = 0x20000
+
+	ddsCapsComplex = 0x8
+	ddsCapsTexture = 0x1000
+	ddsCapsMipMap  = 0x400000
+
+	ddsCaps2Cubemap         = 0x200
+	ddsCaps2CubemapAllFaces = 0xFC00
+
+	ddsResourceDimensionTexture2D = 3
+	ddsResourceMiscTextureCube    = 0x4
+)
+
+type ddsFormat struct {
+	FourCC string
+	// DXGIFormat is written into the DX10 extension header, used when FourCC is DX10 or for texture arrays
+	DXGIFormat uint32
+	// BlockSize is the size of a 4x4 block of compressed formats
+	BlockSize int32
+	// BitsPerPixel is the size of a pixel of uncompressed formats
+	BitsPerPixel int32
+	Flags        uint32
+	Masks        [4]uint32
+}
+
+// https://docs.microsoft.com/en-us/windows/win32/api/dxgiformat/ne-dxgiformat-dxgi_format
+var ddsFormats = map[string]ddsFormat{
+	"PF_DXT1":      {FourCC: "DXT1", DXGIFormat: 71, BlockSize: 8},
+	"PF_DXT3":      {FourCC: "DXT3", DXGIFormat: 74, BlockSize: 16},
+	"PF_DXT5":      {FourCC: "DXT5", DXGIFormat: 77, BlockSize: 16},
+	"PF_BC4":       {FourCC: "ATI1", DXGIFormat: 80, BlockSize: 8},
+	"PF_BC5":       {FourCC: "ATI2", DXGIFormat: 83, BlockSize: 16},
+	"PF_BC6H":      {FourCC: "DX10", DXGIFormat: 95, BlockSize: 16},
+	"PF_BC7":       {FourCC: "DX10", DXGIFormat: 98, BlockSize: 16},
+	"PF_FloatRGBA": {FourCC: "DX10", DXGIFormat: 10, BitsPerPixel: 64},
+	"PF_B8G8R8A8": {
+		DXGIFormat:   87,
+		BitsPerPixel: 32,
+		Flags:        ddpfRGB | ddpfAlphaPixels,
+		Masks:        [4]uint32{0x00ff0000, 0x0000ff00, 0x000000ff, 0xff000000},
+	},
+	"PF_A32B32G32R32F": {FourCC: "DX10", DXGIFormat: 2, BitsPerPixel: 128},
+	"PF_R16F":          {FourCC: "DX10", DXGIFormat: 54, BitsPerPixel: 16},
+	"PF_R8G8B8A8": {
+		DXGIFormat:   28,
+		BitsPerPixel: 32,
+		Flags:        ddpfRGB | ddpfAlphaPixels,
+		Masks:        [4]uint32{0x000000ff, 0x0000ff00, 0x00ff0000, 0xff000000},
+	},
+	"PF_G16": {
+		DXGIFormat:   56,
+		BitsPerPixel: 16,
+		Flags:        ddpfLuminance,
+		Masks:        [4]uint32{0xffff, 0, 0, 0},
+	},
+	"PF_G8": {
+		DXGIFormat:   61,
+		BitsPerPixel: 8,
+		Flags:        ddpfLuminance,
+		Masks:        [4]uint32{0xff, 0, 0, 0},
+	},
+}
+
+// WriteDDS writes the loaded mips of the texture as a DDS file without re-encoding them,
+// starting at the mip at the index or at the largest loaded mip if the index is negative
+func (texture *Texture2D) WriteDDS(w io.Writer, mipIndex int) error {
+	if len(texture.Textures) == 0 {
+		return errors.New("texture has no platform data")
+	}
+
+	platformData := texture.Textures[0]
+	pixelFormat := strings.Trim(platformData.PixelFormat, "\x00")
+
+	format, ok := ddsFormats[pixelFormat]
+	if !ok {
+		return fmt.Errorf("unsupported DDS pixel format: %s", pixelFormat)
+	}
+
+	// Only consecutive loaded mips form a valid mip chain
+	mips := make([]*FTexture2DMipMap, 0)
+	for i, mipMap := range platformData.Mips {
+		if i < mipIndex {
+			continue
+		}
+
+		if mipMap == nil || mipMap.Data.Load() == nil {
+			if len(mips) > 0 {
+				break
+			}
+
+			continue
+		}
+
+		mips = append(mips, mipMap)
+	}
+
+	if len(mips) == 0 {
+		return errors.New("texture has no loaded mips")
+	}
+
+	width := mips[0].SizeX
+	height := mips[0].SizeY
+
+	flags := uint32(ddsdCaps | ddsdHeight | ddsdWidth | ddsdPixelFormat)
+	caps := uint32(ddsCapsTexture)
+
+	var pitchOrLinearSize uint32
+	if format.BlockSize > 0 {
+		flags |= ddsdLinearSize
+		pitchOrLinearSize = uint32(maxInt32(1, (width+3)/4) * maxInt32(1, (height+3)/4) * format.BlockSize)
+	} else {
+		flags |= ddsdPitch
+		pitchOrLinearSize = uint32((width*format.BitsPerPixel + 7) / 8)
+	}
+
+	if len(mips) > 1 {
+		flags |= ddsdMipMapCount
+		caps |= ddsCapsComplex | ddsCapsMipMap
+	}
+
+	slices := texture.Slices(mips[0])
+
+	var caps2 uint32
+	if texture.IsCube {
+		caps |= ddsCapsComplex
+		caps2 = ddsCaps2Cubemap | ddsCaps2CubemapAllFaces
+	}
+
+	// Texture arrays can only be described by the DX10 extension header
+	if slices > 1 && !texture.IsCube && format.FourCC != "DX10" {
+		if format.DXGIFormat == 0 {
+			return fmt.Errorf("unsupported DDS texture array pixel format: %s", pixelFormat)
+		}
+
+		format.FourCC = "DX10"
+		format.Flags = 0
+		format.Masks = [4]uint32{}
+	}
+
+	pixelFlags := format.Flags
+	var fourCC uint32
+	if format.FourCC != "" {
+		pixelFlags |= ddpfFourCC
+		fourCC = binary.LittleEndian.Uint32([]byte(format.FourCC))
+	}
+
+	var rgbBitCount uint32
+	if format.FourCC == "" {
+		rgbBitCount = uint32(format.BitsPerPixel)
+	}
+
+	header := []uint32{
+		0x20534444, // "DDS "
+		124,
+		flags,
+		uint32(height),
+		uint32(width),
+		pitchOrLinearSize,
+		0, // Depth
+		uint32(len(mips)),
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, // Reserved
+		32,
+		pixelFlags,
+		fourCC,
+		rgbBitCount,
+		format.Masks[0],
+		format.Masks[1],
+		format.Masks[2],
+		format.Masks[3],
+		caps,
+		caps2,
+		0, 0, 0, // Caps3, Caps4, Reserved
+	}
+
+	if format.FourCC == "DX10" {
+		var miscFlag uint32
+		arraySize := uint32(slices)
+
+		// The array size of cubemaps counts whole cubes
+		if texture.IsCube {
+			miscFlag = ddsResourceMiscTextureCube
+			arraySize = uint32(maxInt32(1, slices/6))
+		}
+
+		// No alpha mode flags
+		header = append(header, format.DXGIFormat, ddsResourceDimensionTexture2D, miscFlag, arraySize, 0)
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+
+	// Mips hold every slice, while DDS files store the whole mip chain of each slice in turn
+	for slice := int32(0); slice < slices; slice++ {
+		for _, mipMap := range mips {
+			sliceSize := int32(len(mipMap.Data.Data)) / slices
+
+			if _, err := w.Write(mipMap.Data.Data[slice*sliceSize : (slice+1)*sliceSize]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WriteTGA writes an uncompressed 32 bit TGA image
+func WriteTGA(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok {
+		nrgba = image.NewNRGBA(bounds)
+		draw.Draw(nrgba, bounds, img, bounds.Min, draw.Src)
+	}
+
+	header := make([]byte, 18)
+	header[2] = 2 // Uncompressed true-color
+	binary.LittleEndian.PutUint16(header[12:], uint16(bounds.Dx()))
+	binary.LittleEndian.PutUint16(header[14:], uint16(bounds.Dy()))
+	header[16] = 32
+	header[17] = 0x28 // 8 alpha bits, top-left origin
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]byte, bounds.Dx()*4)
+	for y := 0; y < bounds.Dy(); y++ {
+		pixels := nrgba.Pix[y*nrgba.Stride : y*nrgba.Stride+bounds.Dx()*4]
+
+		for x := 0; x < len(pixels); x += 4 {
+			row[x] = pixels[x+2]
+			row[x+1] = pixels[x+1]
+			row[x+2] = pixels[x]
+			row[x+3] = pixels[x+3]
+		}
+
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func maxInt32(a int32, b int32) int32 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Misc/ObjectThumbnail.h
+type FObjectThumbnail struct {
+	ObjectClassName string `json:"object_class_name"`
+	ObjectPath      string `json:"object_path"`
+	ImageWidth      int32  `json:"image_width"`
+	ImageHeight     int32  `json:"image_height"`
+	Format          string `json:"format"`
+
+	CompressedImageData []byte `json:"-"`
+}
+
+const (
+	ThumbnailFormatPNG     = "png"
+	ThumbnailFormatJPEG    = "jpg"
+	ThumbnailFormatUnknown = "bin"
+)
+
+// readThumbnails reads the thumbnail table. The thumbnails precede the table, so their data is provided separately.
+func (parser *PakParser) readThumbnails(summary *FPackageFileSummary, data []byte, dataOffset int32) {
+	count := parser.ReadInt32()
+	if count < 0 {
+		log.Warn().Msgf("Invalid thumbnail count: %d", count)
+		return
+	}
+
+	summary.Thumbnails = make([]*FObjectThumbnail, 0, count)
+
+	for i := int32(0); i < count; i++ {
+		thumbnail := &FObjectThumbnail{
+			ObjectClassName: strings.Trim(parser.ReadString(), "\x00"),
+			ObjectPath:      strings.Trim(parser.ReadString(), "\x00"),
+		}
+
+		// Width, height and size precede the image
+		offset := parser.ReadInt32() - dataOffset
+		if offset < 0 || offset > int32(len(data))-12 {
+			log.Warn().Msgf("Thumbnail of %s is outside of the thumbnail data", thumbnail.ObjectPath)
+			continue
+		}
+
+		thumbnailParser := NewParser(&PakByteReader{Bytes: data[offset:]}, &ParserOptions{NoPreload: true})
+		thumbnail.ImageWidth = thumbnailParser.ReadInt32()
+		thumbnail.ImageHeight = thumbnailParser.ReadInt32()
+
+		// Newer engines store JPEG thumbnails with a negative height
+		if thumbnail.ImageHeight < 0 {
+			thumbnail.ImageHeight = -thumbnail.ImageHeight
+		}
+
+		size := thumbnailParser.ReadInt32()
+		if size > int32(len(data))-offset-12 {
+			log.Warn().Msgf("Image of the thumbnail of %s is outside of the thumbnail data", thumbnail.ObjectPath)
+			continue
+		}
+
+		if size > 0 {
+			thumbnail.CompressedImageData = thumbnailParser.Read(size)
+		}
+
+		switch {
+		case bytes.HasPrefix(thumbnail.CompressedImageData, []byte("\x89PNG")):
+			thumbnail.Format = ThumbnailFormatPNG
+		case bytes.HasPrefix(thumbnail.CompressedImageData, []byte("\xFF\xD8")):
+			thumbnail.Format = ThumbnailFormatJPEG
+		default:
+			thumbnail.Format = ThumbnailFormatUnknown
+		}
+
+		summary.Thumbnails = append(summary.Thumbnails, thumbnail)
+	}
+}
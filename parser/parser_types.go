@@ -15,26 +15,53 @@ func (parser *PakParser) ProcessPak(ctx context.Context, parseFile func(string)
 		return
 	}
 
+	if parser.culture != "" {
+		parser.localization = pak.ReadLocalization(ctx, parser, parser.culture)
+
+		if len(parser.localization.Entries) == 0 {
+			log.Ctx(ctx).Warn().Msgf("No localization found for culture: %s", parser.culture)
+		}
+	}
+
 	summaries := make(map[string]*FPackageFileSummary, 0)
+	stringTables := make(map[string]*FPackageFileSummary, 0)
 
 	// First pass, parse summaries
 	for j, record := range pak.Index.Records {
 		trimmed := strings.Trim(record.FileName, "\x00")
 
-		if parseFile != nil {
-			if !parseFile(trimmed) {
-				continue
-			}
+		if !strings.HasSuffix(trimmed, "uasset") {
+			continue
 		}
 
-		if strings.HasSuffix(trimmed, "uasset") {
-			offset := record.FileOffset + int64(pak.Footer.HeaderSize())
-			log.Ctx(ctx).Info().Msgf("Reading Summary: %d [%x-%x]: %s", j, offset, offset+record.FileSize, trimmed)
-			summaries[trimmed[0:strings.Index(trimmed, ".uasset")]] = record.ReadUAsset(pak, parser)
-			summaries[trimmed[0:strings.Index(trimmed, ".uasset")]].Record = record
+		// Every summary is read when resolving string tables, as texts of any package may reference them
+		process := parseFile == nil || parseFile(trimmed)
+		if !process && parser.stringTables == nil {
+			continue
+		}
+
+		offset := record.DataOffset(pak, parser)
+		log.Ctx(ctx).Info().Msgf("Reading Summary: %d [%x-%x]: %s", j, offset, offset+record.FileSize, trimmed)
+
+		summary := readSummary(ctx, pak, parser, record)
+		if summary == nil {
+			continue
+		}
+
+		summary.Record = record
+		name := trimmed[0:strings.Index(trimmed, ".uasset")]
+
+		if process {
+			summaries[name] = summary
+		}
+
+		if parser.stringTables != nil && hasStringTable(summary) {
+			stringTables[name] = summary
 		}
 	}
 
+	parser.readStringTables(ctx, pak, stringTables)
+
 	// Second pass, parse exports
 	for j, record := range pak.Index.Records {
 		trimmed := strings.Trim(record.FileName, "\x00")
@@ -48,7 +75,7 @@ func (parser *PakParser) ProcessPak(ctx context.Context, parseFile func(string)
 		if strings.HasSuffix(trimmed, "uexp") {
 			summary, ok := summaries[trimmed[0:strings.Index(trimmed, ".uexp")]]
 
-			offset := record.FileOffset + int64(pak.Footer.HeaderSize())
+			offset := record.DataOffset(pak, parser)
 
 			if !ok {
 				log.Ctx(ctx).Error().Msgf("Unable to read record. Missing uasset: %d [%x-%x]: %s", j, offset, offset+record.FileSize, trimmed)
@@ -79,3 +106,15 @@ func (parser *PakParser) ProcessPak(ctx context.Context, parseFile func(string)
 		}
 	}
 }
+
+// readSummary reads the summary of the record, returning nil if the package could not be read
+func readSummary(ctx context.Context, pak *PakFile, parser *PakParser, record *FPakEntry) (summary *FPackageFileSummary) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Ctx(ctx).Error().Str("stack", string(debug.Stack())).Msgf("error parsing summary: %v", err)
+			summary = nil
+		}
+	}()
+
+	return record.ReadUAsset(pak, parser)
+}
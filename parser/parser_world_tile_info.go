@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Engine/WorldComposition.h
+type FWorldTileInfo struct {
+	Position              *FIntVector          `json:"position"`
+	Bounds                *FBox                `json:"bounds"`
+	Layer                 *FWorldTileLayer     `json:"layer"`
+	HideInTileView        bool                 `json:"hide_in_tile_view"`
+	ParentTilePackageName string               `json:"parent_tile_package_name"`
+	LODList               []*FWorldTileLODInfo `json:"lod_list"`
+	ZOrder                int32                `json:"z_order"`
+}
+
+type FWorldTileLayer struct {
+	Name                     string     `json:"name"`
+	Reserved0                int32      `json:"reserved_0"`
+	Reserved1                *FIntPoint `json:"reserved_1"`
+	StreamingDistance        int32      `json:"streaming_distance"`
+	DistanceStreamingEnabled bool       `json:"distance_streaming_enabled"`
+}
+
+type FWorldTileLODInfo struct {
+	RelativeStreamingDistance int32   `json:"relative_streaming_distance"`
+	Reserved0                 float32 `json:"reserved_0"`
+	Reserved1                 float32 `json:"reserved_1"`
+	Reserved2                 int32   `json:"reserved_2"`
+	Reserved3                 int32   `json:"reserved_3"`
+}
+
+func (parser *PakParser) ReadFWorldTileInfo() *FWorldTileInfo {
+	info := &FWorldTileInfo{
+		Position: parser.ReadFIntVector(),
+		Bounds:   parser.ReadFBox(),
+		Layer: &FWorldTileLayer{
+			Name:                     strings.Trim(parser.ReadString(), "\x00"),
+			Reserved0:                parser.ReadInt32(),
+			Reserved1:                parser.ReadFIntPoint(),
+			StreamingDistance:        parser.ReadInt32(),
+			DistanceStreamingEnabled: parser.ReadInt32() != 0,
+		},
+		HideInTileView:        parser.ReadInt32() != 0,
+		ParentTilePackageName: strings.Trim(parser.ReadString(), "\x00"),
+	}
+
+	count := parser.ReadInt32()
+	if count < 0 {
+		log.Warn().Msgf("Invalid world tile LOD count: %d", count)
+		return info
+	}
+
+	info.LODList = make([]*FWorldTileLODInfo, count)
+	for i := range info.LODList {
+		info.LODList[i] = &FWorldTileLODInfo{
+			RelativeStreamingDistance: parser.ReadInt32(),
+			Reserved0:                 parser.ReadFloat32(),
+			Reserved1:                 parser.ReadFloat32(),
+			Reserved2:                 parser.ReadInt32(),
+			Reserved3:                 parser.ReadInt32(),
+		}
+	}
+
+	info.ZOrder = parser.ReadInt32()
+
+	return info
+}
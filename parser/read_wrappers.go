@@ -39,7 +39,6 @@ type PakZlibReader struct {
 
 func (reader *PakZlibReader) Seek(_ int64, _ int) (ret int64, err error) {
 	panic("Tried to seek on ZLIB reader")
-	return 0, nil
 }
 
 func (reader *PakZlibReader) Read(b []byte) (n int, err error) {
@@ -0,0 +1,122 @@
+package parser
+
+import "sync"
+
+// Registry holds the struct resolvers, class resolvers and map property overrides used by a parser.
+// A registry is safe for concurrent use and may be shared between parsers.
+type Registry struct {
+	mutex sync.RWMutex
+
+	structResolvers      map[string]StructResolver
+	structNativeSizes    map[string]int32
+	classResolvers       map[string]ClassResolver
+	mapPropertyOverrides map[string]*MapProperty
+}
+
+// builtins is the registry every default registry is cloned from
+var builtins = &Registry{
+	structResolvers:      structResolvers,
+	structNativeSizes:    structNativeSizes,
+	classResolvers:       classResolvers,
+	mapPropertyOverrides: mapPropertyTypeOverrides,
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		structResolvers:      make(map[string]StructResolver),
+		structNativeSizes:    make(map[string]int32),
+		classResolvers:       make(map[string]ClassResolver),
+		mapPropertyOverrides: make(map[string]*MapProperty),
+	}
+}
+
+// DefaultRegistry creates a registry containing a copy of the built-in resolvers and overrides
+func DefaultRegistry() *Registry {
+	return builtins.Clone()
+}
+
+// Clone creates an independent copy of the registry
+func (registry *Registry) Clone() *Registry {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	clone := &Registry{
+		structResolvers:      make(map[string]StructResolver, len(registry.structResolvers)),
+		structNativeSizes:    make(map[string]int32, len(registry.structNativeSizes)),
+		classResolvers:       make(map[string]ClassResolver, len(registry.classResolvers)),
+		mapPropertyOverrides: make(map[string]*MapProperty, len(registry.mapPropertyOverrides)),
+	}
+
+	for name, resolver := range registry.structResolvers {
+		clone.structResolvers[name] = resolver
+	}
+
+	for name, size := range registry.structNativeSizes {
+		clone.structNativeSizes[name] = size
+	}
+
+	for name, resolver := range registry.classResolvers {
+		clone.classResolvers[name] = resolver
+	}
+
+	for name, override := range registry.mapPropertyOverrides {
+		clone.mapPropertyOverrides[name] = override
+	}
+
+	return clone
+}
+
+func (registry *Registry) RegisterStructResolver(structType string, resolver StructResolver) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.structResolvers[structType] = resolver
+}
+
+// StructResolver returns the resolver of the struct type. A registered resolver may be nil.
+func (registry *Registry) StructResolver(structType string) (StructResolver, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	resolver, ok := registry.structResolvers[structType]
+	return resolver, ok
+}
+
+func (registry *Registry) RegisterStructNativeSize(structType string, size int32) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.structNativeSizes[structType] = size
+}
+
+func (registry *Registry) StructNativeSize(structType string) (int32, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	size, ok := registry.structNativeSizes[structType]
+	return size, ok
+}
+
+func (registry *Registry) RegisterClassResolver(classType string, resolver ClassResolver) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.classResolvers[classType] = resolver
+}
+
+// ClassResolver returns the resolver of the class type. A registered resolver may be nil.
+func (registry *Registry) ClassResolver(classType string) (ClassResolver, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	resolver, ok := registry.classResolvers[classType]
+	return resolver, ok
+}
+
+func (registry *Registry) RegisterMapPropertyOverride(name string, override *MapProperty) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.mapPropertyOverrides[name] = override
+}
+
+func (registry *Registry) MapPropertyOverride(name string) (*MapProperty, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	override, ok := registry.mapPropertyOverrides[name]
+	return override, ok
+}
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Schema describes struct and class layouts without having to write a resolver in Go
+type Schema struct {
+	Structs      map[string]*SchemaStruct      `json:"structs" yaml:"structs"`
+	Classes      map[string]*SchemaClass       `json:"classes" yaml:"classes"`
+	MapOverrides map[string]*SchemaMapOverride `json:"map_overrides" yaml:"map_overrides"`
+}
+
+type SchemaStruct struct {
+	// NativeSize is the fixed serialized size of the struct, 0 if the size is variable
+	NativeSize int32          `json:"native_size" yaml:"native_size"`
+	Fields     []*SchemaField `json:"fields" yaml:"fields"`
+}
+
+type SchemaClass struct {
+	Fields []*SchemaField `json:"fields" yaml:"fields"`
+}
+
+type SchemaMapOverride struct {
+	KeyType   string `json:"key_type" yaml:"key_type"`
+	ValueType string `json:"value_type" yaml:"value_type"`
+}
+
+type SchemaField struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+
+	// Element describes the elements of an array field
+	Element *SchemaField `json:"element" yaml:"element"`
+
+	// Count is the fixed element count of an array field, if 0 the array is prefixed by an int32 count
+	Count int32 `json:"count" yaml:"count"`
+
+	// Struct is the struct type of a struct field
+	Struct string `json:"struct" yaml:"struct"`
+}
+
+var schemaFieldTypes = map[string]bool{
+	"int8":             true,
+	"uint8":            true,
+	"int16":            true,
+	"uint16":           true,
+	"int32":            true,
+	"uint32":           true,
+	"int64":            true,
+	"uint64":           true,
+	"float":            true,
+	"double":           true,
+	"bool":             true,
+	"bool8":            true,
+	"string":           true,
+	"name":             true,
+	"text":             true,
+	"guid":             true,
+	"object":           true,
+	"soft_object_path": true,
+	"properties":       true,
+	"array":            true,
+	"struct":           true,
+}
+
+// LoadSchema reads a schema from a .json, .yaml or .yml file
+func LoadSchema(path string) (*Schema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSchema(data, strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// ParseSchema decodes and validates a schema in the provided format (json or yaml)
+func ParseSchema(data []byte, format string) (*Schema, error) {
+	schema := &Schema{}
+
+	var err error
+	switch strings.ToLower(format) {
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(schema)
+	case "yaml", "yml":
+		err = yaml.UnmarshalStrict(data, schema)
+	default:
+		return nil, fmt.Errorf("unknown schema format: %s", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func (schema *Schema) Validate() error {
+	for name, structSchema := range schema.Structs {
+		for _, field := range structSchema.Fields {
+			if err := field.validate(); err != nil {
+				return fmt.Errorf("struct %s: %w", name, err)
+			}
+		}
+	}
+
+	for name, classSchema := range schema.Classes {
+		for _, field := range classSchema.Fields {
+			if err := field.validate(); err != nil {
+				return fmt.Errorf("class %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (field *SchemaField) validate() error {
+	if !schemaFieldTypes[field.Type] {
+		return fmt.Errorf("field %s: unknown type: %s", field.Name, field.Type)
+	}
+
+	switch field.Type {
+	case "array":
+		if field.Element == nil {
+			return fmt.Errorf("field %s: array without element", field.Name)
+		}
+
+		return field.Element.validate()
+	case "struct":
+		if field.Struct == "" {
+			return fmt.Errorf("field %s: struct without type", field.Name)
+		}
+	}
+
+	return nil
+}
+
+// Register adds resolvers for every struct and class in the schema to the registry, replacing existing ones
+func (schema *Schema) Register(registry *Registry) {
+	for name, structSchema := range schema.Structs {
+		registry.RegisterStructResolver(name, structSchema.Resolver())
+
+		if structSchema.NativeSize > 0 {
+			registry.RegisterStructNativeSize(name, structSchema.NativeSize)
+		}
+	}
+
+	for name, classSchema := range schema.Classes {
+		registry.RegisterClassResolver(name, classSchema.Resolver())
+	}
+
+	for name, override := range schema.MapOverrides {
+		registry.RegisterMapPropertyOverride(name, &MapProperty{
+			KeyType:   override.KeyType,
+			ValueType: override.ValueType,
+		})
+	}
+}
+
+func (structSchema *SchemaStruct) Resolver() StructResolver {
+	return func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadSchemaFields(ctx, structSchema.Fields, uAsset)
+	}
+}
+
+func (classSchema *SchemaClass) Resolver() ClassResolver {
+	return func(ctx context.Context, parser *PakParser, export *FObjectExport, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadSchemaFields(ctx, classSchema.Fields, uAsset)
+	}
+}
+
+func (parser *PakParser) ReadSchemaFields(ctx context.Context, fields []*SchemaField, uAsset *FPackageFileSummary) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields))
+
+	for _, field := range fields {
+		result[field.Name] = parser.ReadSchemaField(ctx, field, uAsset)
+	}
+
+	return result
+}
+
+func (parser *PakParser) ReadSchemaField(ctx context.Context, field *SchemaField, uAsset *FPackageFileSummary) interface{} {
+	switch field.Type {
+	case "int8":
+		return int8(parser.Read(1)[0])
+	case "uint8":
+		return parser.Read(1)[0]
+	case "int16":
+		return int16(parser.ReadUint16())
+	case "uint16":
+		return parser.ReadUint16()
+	case "int32":
+		return parser.ReadInt32()
+	case "uint32":
+		return parser.ReadUint32()
+	case "int64":
+		return parser.ReadInt64()
+	case "uint64":
+		return parser.ReadUint64()
+	case "float":
+		return parser.ReadFloat32()
+	case "double":
+		return parser.ReadFloat64()
+	case "bool":
+		return parser.ReadInt32() != 0
+	case "bool8":
+		return parser.Read(1)[0] != 0
+	case "string":
+		return parser.ReadString()
+	case "name":
+		return parser.ReadFName(uAsset.Names)
+	case "text":
+		return parser.ReadFText(uAsset)
+	case "guid":
+		return parser.ReadFGuid()
+	case "object":
+		return parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports)
+	case "soft_object_path":
+		return parser.ReadFSoftObjectPath(uAsset.Names)
+	case "properties":
+		return parser.ReadFPropertyTagLoop(ctx, uAsset)
+	case "array":
+		count := field.Count
+		if count == 0 {
+			count = parser.ReadInt32()
+		}
+
+		values := make([]interface{}, count)
+		for i := int32(0); i < count; i++ {
+			values[i] = parser.ReadSchemaField(ctx, field.Element, uAsset)
+		}
+
+		return values
+	case "struct":
+		if resolver, _ := parser.registry.StructResolver(field.Struct); resolver != nil {
+			if value := resolver(ctx, parser, &StructProperty{Type: field.Struct}, -1, uAsset); value != nil {
+				return &StructType{
+					Type:  field.Struct,
+					Value: value,
+				}
+			}
+		}
+
+		return &StructType{
+			Type:  field.Struct,
+			Value: parser.ReadFPropertyTagLoop(ctx, uAsset),
+		}
+	}
+
+	panic("unknown schema field type: " + field.Type)
+}
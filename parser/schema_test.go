@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestParseSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+		valid  bool
+	}{
+		{"json", `{"structs": {"Pair": {"native_size": 8, "fields": [{"name": "A", "type": "int32"}, {"name": "B", "type": "float"}]}}}`, "json", true},
+		{"yaml", "classes:\n  Item:\n    fields:\n      - name: Tags\n        type: array\n        element:\n          type: name\n", "yaml", true},
+		{"json unknown field", `{"structs": {"Pair": {"nativesize": 8}}}`, "json", false},
+		{"yaml unknown field", "structs:\n  Pair:\n    nativesize: 8\n", "yml", false},
+		{"unknown type", `{"structs": {"Pair": {"fields": [{"name": "A", "type": "int128"}]}}}`, "json", false},
+		{"unknown element type", "classes:\n  Item:\n    fields:\n      - name: Tags\n        type: array\n        element:\n          type: names\n", "yaml", false},
+		{"array without element", `{"classes": {"Item": {"fields": [{"name": "Tags", "type": "array"}]}}}`, "json", false},
+		{"struct without type", `{"classes": {"Item": {"fields": [{"name": "Pair", "type": "struct"}]}}}`, "json", false},
+		{"unknown format", `{}`, "toml", false},
+	}
+
+	for _, test := range tests {
+		schema, err := ParseSchema([]byte(test.data), test.format)
+
+		if test.valid && (err != nil || schema == nil) {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestReadSchemaFields(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{"classes": {"Item": {"fields": [
+		{"name": "Count", "type": "int32"},
+		{"name": "Scale", "type": "float"},
+		{"name": "Enabled", "type": "bool8"},
+		{"name": "Label", "type": "string"},
+		{"name": "Tag", "type": "name"},
+		{"name": "Sizes", "type": "array", "count": 2, "element": {"type": "uint16"}},
+		{"name": "Offsets", "type": "array", "element": {"type": "int8"}},
+		{"name": "Location", "type": "struct", "struct": "Vector"}
+	]}}}`), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buffer bytes.Buffer
+	write := func(values ...interface{}) {
+		for _, value := range values {
+			if text, ok := value.(string); ok {
+				buffer.WriteString(text)
+			} else if err := binary.Write(&buffer, binary.LittleEndian, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	write(int32(-3), float32(1.5), uint8(1))
+	write(int32(4), "abc\x00")
+	write(uint32(1), uint32(0))
+	write(uint16(7), uint16(9))
+	write(int32(2), uint8(0xFF), uint8(2))
+	write(float32(1), float32(2), float32(3))
+
+	data := buffer.Bytes()
+
+	parser := NewParser(&PakByteReader{Bytes: data}, &ParserOptions{NoPreload: true})
+	uAsset := &FPackageFileSummary{
+		Names: []*FNameEntrySerialized{{Name: "None"}, {Name: "Tag"}},
+	}
+
+	got := parser.ReadSchemaFields(context.Background(), schema.Classes["Item"].Fields, uAsset)
+
+	want := map[string]interface{}{
+		"Count":    int32(-3),
+		"Scale":    float32(1.5),
+		"Enabled":  true,
+		"Label":    "abc\x00",
+		"Tag":      "Tag",
+		"Sizes":    []interface{}{uint16(7), uint16(9)},
+		"Offsets":  []interface{}{int8(-1), int8(2)},
+		"Location": &StructType{Type: "Vector", Value: &FVector{X: 1, Y: 2, Z: 3}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadSchemaFields = %#v, want %#v", got, want)
+	}
+
+	if read := parser.Tell(); read != int64(len(data)) {
+		t.Errorf("read %d bytes, want %d", read, len(data))
+	}
+}
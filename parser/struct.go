@@ -6,116 +6,178 @@ import (
 	"strings"
 )
 
-type StructResolver func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{}
+type StructResolver func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{}
 
 var structResolvers = map[string]StructResolver{
-	"Vector": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Vector": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFVector()
 	},
-	"LinearColor": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"LinearColor": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFLinearColor()
 	},
-	"Vector2D": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Vector2D": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFVector2D()
 	},
-	"IntPoint": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"IntPoint": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFIntPoint()
 	},
-	"Rotator": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Rotator": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFRotator()
 	},
-	"Quat": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Quat": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFQuat()
 	},
-	"Vector4": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Vector4": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFVector4()
 	},
-	"Color": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Color": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFColor()
 	},
-	"Box": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Box": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFBox()
 	},
-	"FrameNumber": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"FrameNumber": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFFrameNumber()
 	},
-	"MovieSceneSequenceID": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"MovieSceneSequenceID": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFMovieSceneSequenceID()
 	},
-	"Box2D": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"Box2D": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFBox2D()
 	},
-	"MovieSceneTrackIdentifier": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"MovieSceneTrackIdentifier": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFMovieSceneTrackIdentifier()
 	},
-	"MovieSceneEvaluationKey": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"MovieSceneEvaluationKey": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFMovieSceneEvaluationKey()
 	},
-	"MovieSceneSegmentIdentifier": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"MovieSceneSegmentIdentifier": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFMovieSceneSegmentIdentifier()
 	},
-	"MovieSceneFloatValue": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"MovieSceneFloatValue": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFMovieSceneFloatValue()
 	},
-	"RichCurveKey": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"RichCurveKey": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFRichCurveKey()
 	},
-	"MovieSceneFrameRange": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"SimpleCurveKey": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFSimpleCurveKey()
+	},
+	"MovieSceneFrameRange": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFMovieSceneFrameRange()
 	},
-	"Guid": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
-		if size == 16 {
-			return parser.ReadFGuid()
-		}
-
-		// TODO Something is not right
-		// return parser.ReadFGuid()
-		return nil
+	"Guid": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFGuid()
 	},
-	"VectorMaterialInput": nil,
-	"ExpressionInput": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
-		// Unsure if read correctly
-		if size != 40 {
-			return nil
-		}
-
+	"VectorMaterialInput": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFVectorMaterialInput(uAsset.Names)
+	},
+	"ExpressionInput": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFExpressionInput(uAsset.Names)
 	},
-	"IntVector": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+	"IntVector": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
 		return parser.ReadFIntVector()
 	},
-	"SoftObjectPath": func(parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
-		return &FSoftObjectPath{
-			AssetPathName: parser.ReadFName(uAsset.Names),
-			SubPath:       parser.ReadString(),
-		}
+	"SoftObjectPath": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFSoftObjectPath(uAsset.Names)
+	},
+	"SoftClassPath": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFSoftObjectPath(uAsset.Names)
+	},
+	"ScalarMaterialInput": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFScalarMaterialInput(uAsset.Names)
+	},
+	"ColorMaterialInput": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFColorMaterialInput(uAsset.Names)
+	},
+	"MaterialAttributesInput": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMaterialAttributesInput(uAsset.Names)
+	},
+	"PerPlatformFloat": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFPerPlatformFloat(uAsset.Names)
+	},
+	"PerPlatformInt": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFPerPlatformInt(uAsset.Names)
+	},
+	"FontData": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFFontData(uAsset)
+	},
+	"FontCharacter": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFFontCharacter()
+	},
+	"SmartName": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFSmartName(uAsset.Names)
+	},
+	"MovieSceneByteChannel": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneByteChannel()
+	},
+	"MovieSceneParticleChannel": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		// Particle channels are byte channels holding EParticleKey values
+		return parser.ReadFMovieSceneByteChannel()
+	},
+	"MovieSceneEventParameters": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneEventParameters(uAsset.Names)
+	},
+	"SkeletalMeshSamplingLODBuiltData": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFSkeletalMeshSamplingLODBuiltData()
 	},
-	"ScalarMaterialInput":                 nil,
-	"ColorMaterialInput":                  nil,
-	"PerPlatformFloat":                    nil,
-	"SkeletalMeshSamplingLODBuiltData":    nil,
-	"PointerToUberGraphFrame":             nil,
-	"FontData":                            nil,
-	"FontCharacter":                       nil,
-	"MaterialAttributesInput":             nil,
-	"MovieSceneByteChannel":               nil,
-	"MovieSceneEventParameters":           nil,
-	"SoftClassPath":                       nil,
-	"MovieSceneParticleChannel":           nil,
-	"SmartName":                           nil,
-	"PerPlatformInt":                      nil,
-	"MovieSceneSegment":                   nil,
-	"SectionEvaluationDataTree":           nil,
-	"MovieSceneEvalTemplatePtr":           nil,
-	"MovieSceneTrackImplementationPtr":    nil,
-	"MovieSceneEvaluationTrack":           nil,
-	"MovieSceneFloatChannel":              nil,
-	"LevelSequenceBindingReferenceArray":  nil,
-	"MovieSceneTrackFieldData":            nil,
-	"NiagaraDataInterfaceGPUParamInfo":    nil,
-	"MovieSceneEvaluationFieldEntityTree": nil,
-	"NiagaraVariable":                     nil,
-	"NiagaraVariableWithOffset":           nil,
+	// PointerToUberGraphFrame has no serialized fields, it is read as an empty tagged struct
+	"SectionEvaluationDataTree": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFSectionEvaluationDataTree(ctx, uAsset)
+	},
+	"MovieSceneFloatChannel": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneFloatChannel()
+	},
+	// LevelSequenceBindingReferenceArray has no native serializer, it is read as tagged properties
+	"MovieSceneTrackFieldData": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneTrackFieldData()
+	},
+	// NiagaraDataInterfaceGPUParamInfo has no native serializer in 4.22, it is read as tagged properties
+	"MovieSceneEvaluationFieldEntityTree": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneEvaluationFieldEntityTree()
+	},
+	// NiagaraVariable and NiagaraVariableWithOffset have no native serializer in 4.22, they are read as tagged properties
+	"MovieSceneSegment": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneSegment(ctx, uAsset)
+	},
+	"MovieSceneEvalTemplatePtr": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneEvalTemplatePtr(ctx, uAsset)
+	},
+	"MovieSceneTrackImplementationPtr": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneTrackImplementationPtr(ctx, uAsset)
+	},
+	"MovieSceneEvaluationTrack": func(ctx context.Context, parser *PakParser, property *StructProperty, size int32, uAsset *FPackageFileSummary) interface{} {
+		return parser.ReadFMovieSceneEvaluationTrack(ctx, uAsset)
+	},
+}
+
+// structNativeSizes holds the serialized size of natively serialized structs with a fixed layout.
+// If the size declared for such a struct differs, it was serialized as tagged properties instead.
+var structNativeSizes = map[string]int32{
+	"Vector":                      12,
+	"LinearColor":                 16,
+	"Vector2D":                    8,
+	"IntPoint":                    8,
+	"Rotator":                     12,
+	"Quat":                        16,
+	"Vector4":                     16,
+	"Color":                       4,
+	"Box":                         25,
+	"FrameNumber":                 4,
+	"MovieSceneSequenceID":        4,
+	"Box2D":                       17,
+	"MovieSceneTrackIdentifier":   4,
+	"MovieSceneEvaluationKey":     12,
+	"MovieSceneSegmentIdentifier": 4,
+	"MovieSceneFloatValue":        23,
+	"RichCurveKey":                27,
+	"SimpleCurveKey":              8,
+	"MovieSceneFrameRange":        10,
+	"Guid":                        16,
+	"ExpressionInput":             40,
+	"IntVector":                   12,
+	"FontCharacter":               21,
+	"SmartName":                   8,
 }
 
 type StructType struct {
@@ -126,14 +188,19 @@ type StructType struct {
 func (parser *PakParser) ReadStruct(ctx context.Context, property *StructProperty, size int32, uAsset *FPackageFileSummary, depth int) (interface{}, bool) {
 	trimmedType := strings.Trim(property.Type, "\x00")
 
-	resolver, ok := structResolvers[trimmedType]
+	resolver, ok := parser.registry.StructResolver(trimmedType)
 
 	if !ok {
 		return nil, false
 	}
 
+	if !parser.IsNativeStructSize(trimmedType, size, 1) {
+		log.Ctx(ctx).Trace().Msgf("%sStructProperty %s is tagged [%d]", d(depth), trimmedType, size)
+		return nil, false
+	}
+
 	if resolver != nil {
-		value := resolver(parser, property, size, uAsset)
+		value := resolver(ctx, parser, property, size, uAsset)
 
 		if value != nil {
 			return value, true
@@ -142,12 +209,30 @@ func (parser *PakParser) ReadStruct(ctx context.Context, property *StructPropert
 
 	log.Ctx(ctx).Warn().Msgf("%sUnread StructProperty Type [%d]: %s", d(depth), size, trimmedType)
 	if size > 0 {
-		parser.Read(size)
+		return parser.ReadRawData(size), true
 	}
 
 	return nil, true
 }
 
+// RegisterStructResolver registers a built-in resolver included in every default registry created afterwards
 func RegisterStructResolver(structType string, resolver StructResolver) {
-	structResolvers[structType] = resolver
+	builtins.RegisterStructResolver(structType, resolver)
+}
+
+// IsNativeStructSize reports whether count structs of the provided type can occupy size bytes using their native layout.
+// Negative sizes are unknown and always match.
+func (parser *PakParser) IsNativeStructSize(structType string, size int32, count int32) bool {
+	nativeSize, ok := parser.registry.StructNativeSize(structType)
+
+	if !ok || size < 0 {
+		return true
+	}
+
+	return nativeSize*count == size
+}
+
+// RegisterStructNativeSize registers a built-in native size included in every default registry created afterwards
+func RegisterStructNativeSize(structType string, size int32) {
+	builtins.RegisterStructNativeSize(structType, size)
 }
@@ -2,25 +2,27 @@ package parser
 
 // https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Materials/MaterialExpression.h#L22
 type FExpressionInput struct {
-	Expression     uint32 `json:"expression"`
-	ExpressionName string `json:"expression_name"`
+	OutputIndex    int32  `json:"output_index"`
+	InputName      string `json:"input_name"`
 	Mask           int32  `json:"mask"`
 	MaskR          int32  `json:"mask_r"`
 	MaskG          int32  `json:"mask_g"`
 	MaskB          int32  `json:"mask_b"`
 	MaskA          int32  `json:"mask_a"`
-	OutputIndex    int32  `json:"output_index"`
+	ExpressionName string `json:"expression_name"`
 }
 
+// ReadFExpressionInput reads the native layout of cooked inputs, which do not save the expression object
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Private/Materials/MaterialShared.cpp
 func (parser *PakParser) ReadFExpressionInput(names []*FNameEntrySerialized) *FExpressionInput {
 	return &FExpressionInput{
-		Expression:     parser.ReadUint32(),
-		ExpressionName: parser.ReadFName(names),
+		OutputIndex:    parser.ReadInt32(),
+		InputName:      parser.ReadFName(names),
 		Mask:           parser.ReadInt32(),
 		MaskR:          parser.ReadInt32(),
 		MaskG:          parser.ReadInt32(),
 		MaskB:          parser.ReadInt32(),
 		MaskA:          parser.ReadInt32(),
-		OutputIndex:    parser.ReadInt32(),
+		ExpressionName: parser.ReadFName(names),
 	}
 }
@@ -0,0 +1,22 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Engine/Font.h
+type FFontCharacter struct {
+	StartU         int32 `json:"start_u"`
+	StartV         int32 `json:"start_v"`
+	USize          int32 `json:"u_size"`
+	VSize          int32 `json:"v_size"`
+	TextureIndex   uint8 `json:"texture_index"`
+	VerticalOffset int32 `json:"vertical_offset"`
+}
+
+func (parser *PakParser) ReadFFontCharacter() *FFontCharacter {
+	return &FFontCharacter{
+		StartU:         parser.ReadInt32(),
+		StartV:         parser.ReadInt32(),
+		USize:          parser.ReadInt32(),
+		VSize:          parser.ReadInt32(),
+		TextureIndex:   parser.Read(1)[0],
+		VerticalOffset: parser.ReadInt32(),
+	}
+}
@@ -0,0 +1,35 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/SlateCore/Public/Fonts/CompositeFont.h
+type FFontData struct {
+	Cooked        bool           `json:"cooked"`
+	FontFaceAsset *FPackageIndex `json:"font_face_asset"`
+	FontFilename  string         `json:"font_filename"`
+	Hinting       uint8          `json:"hinting"`
+	LoadingPolicy uint8          `json:"loading_policy"`
+	SubFaceIndex  int32          `json:"sub_face_index"`
+}
+
+func (parser *PakParser) ReadFFontData(uAsset *FPackageFileSummary) *FFontData {
+	data := &FFontData{
+		Cooked: parser.ReadInt32() != 0,
+	}
+
+	if data.Cooked {
+		data.FontFaceAsset = parser.ReadFPackageIndex(uAsset.Imports, uAsset.Exports)
+
+		if data.FontFaceAsset == nil || data.FontFaceAsset.Reference == nil {
+			data.FontFilename = parser.ReadString()
+			data.Hinting = parser.Read(1)[0]
+			data.LoadingPolicy = parser.Read(1)[0]
+		}
+	} else {
+		data.FontFilename = parser.ReadString()
+		data.Hinting = parser.Read(1)[0]
+		data.LoadingPolicy = parser.Read(1)[0]
+	}
+
+	data.SubFaceIndex = parser.ReadInt32()
+
+	return data
+}
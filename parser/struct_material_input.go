@@ -0,0 +1,59 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Materials/Material.h
+type FColorMaterialInput struct {
+	*FExpressionInput
+	UseConstant bool    `json:"use_constant"`
+	Constant    *FColor `json:"constant"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Materials/Material.h
+type FScalarMaterialInput struct {
+	*FExpressionInput
+	UseConstant bool    `json:"use_constant"`
+	Constant    float32 `json:"constant"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Materials/Material.h
+type FVectorMaterialInput struct {
+	*FExpressionInput
+	UseConstant bool     `json:"use_constant"`
+	Constant    *FVector `json:"constant"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Materials/Material.h
+type FMaterialAttributesInput struct {
+	*FExpressionInput
+	PropertyConnectedBitmask uint32 `json:"property_connected_bitmask"`
+}
+
+func (parser *PakParser) ReadFColorMaterialInput(names []*FNameEntrySerialized) *FColorMaterialInput {
+	return &FColorMaterialInput{
+		FExpressionInput: parser.ReadFExpressionInput(names),
+		UseConstant:      parser.ReadInt32() != 0,
+		Constant:         parser.ReadFColor(),
+	}
+}
+
+func (parser *PakParser) ReadFScalarMaterialInput(names []*FNameEntrySerialized) *FScalarMaterialInput {
+	return &FScalarMaterialInput{
+		FExpressionInput: parser.ReadFExpressionInput(names),
+		UseConstant:      parser.ReadInt32() != 0,
+		Constant:         parser.ReadFloat32(),
+	}
+}
+
+func (parser *PakParser) ReadFVectorMaterialInput(names []*FNameEntrySerialized) *FVectorMaterialInput {
+	return &FVectorMaterialInput{
+		FExpressionInput: parser.ReadFExpressionInput(names),
+		UseConstant:      parser.ReadInt32() != 0,
+		Constant:         parser.ReadFVector(),
+	}
+}
+
+func (parser *PakParser) ReadFMaterialAttributesInput(names []*FNameEntrySerialized) *FMaterialAttributesInput {
+	return &FMaterialAttributesInput{
+		FExpressionInput:         parser.ReadFExpressionInput(names),
+		PropertyConnectedBitmask: parser.ReadUint32(),
+	}
+}
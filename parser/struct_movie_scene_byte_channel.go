@@ -0,0 +1,26 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Channels/MovieSceneByteChannel.h
+type FMovieSceneByteChannel struct {
+	Times           []*FFrameNumber `json:"times"`
+	Values          []uint8         `json:"values"`
+	DefaultValue    uint8           `json:"default_value"`
+	HasDefaultValue bool            `json:"has_default_value"`
+}
+
+func (parser *PakParser) ReadFMovieSceneByteChannel() *FMovieSceneByteChannel {
+	timeCount := parser.ReadUint32()
+	times := make([]*FFrameNumber, timeCount)
+	for i := uint32(0); i < timeCount; i++ {
+		times[i] = parser.ReadFFrameNumber()
+	}
+
+	valueCount := parser.ReadUint32()
+
+	return &FMovieSceneByteChannel{
+		Times:           times,
+		Values:          parser.Read(int32(valueCount)),
+		DefaultValue:    parser.Read(1)[0],
+		HasDefaultValue: parser.ReadInt32() != 0,
+	}
+}
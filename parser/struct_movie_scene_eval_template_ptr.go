@@ -0,0 +1,44 @@
+package parser
+
+import "context"
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvalTemplate.h
+type FMovieSceneEvalTemplatePtr struct {
+	TypeName string          `json:"type_name"`
+	Data     []*FPropertyTag `json:"data"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneTrackImplementation.h
+type FMovieSceneTrackImplementationPtr struct {
+	TypeName string          `json:"type_name"`
+	Data     []*FPropertyTag `json:"data"`
+}
+
+func (parser *PakParser) ReadFMovieSceneEvalTemplatePtr(ctx context.Context, uAsset *FPackageFileSummary) *FMovieSceneEvalTemplatePtr {
+	typeName, data := parser.readInlineStruct(ctx, uAsset)
+
+	return &FMovieSceneEvalTemplatePtr{
+		TypeName: typeName,
+		Data:     data,
+	}
+}
+
+func (parser *PakParser) ReadFMovieSceneTrackImplementationPtr(ctx context.Context, uAsset *FPackageFileSummary) *FMovieSceneTrackImplementationPtr {
+	typeName, data := parser.readInlineStruct(ctx, uAsset)
+
+	return &FMovieSceneTrackImplementationPtr{
+		TypeName: typeName,
+		Data:     data,
+	}
+}
+
+// readInlineStruct reads a TInlineValue, which is the struct path followed by its tagged properties
+func (parser *PakParser) readInlineStruct(ctx context.Context, uAsset *FPackageFileSummary) (string, []*FPropertyTag) {
+	typeName := parser.ReadString()
+
+	if typeName == "" {
+		return typeName, nil
+	}
+
+	return typeName, parser.ReadFPropertyTagLoop(ctx, uAsset)
+}
@@ -0,0 +1,23 @@
+package parser
+
+// https://github.com/EpicGames/UnrealEngine/blob/4.26/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationField.h
+type FMovieSceneEvaluationFieldEntityTree struct {
+	SerializedData *FMovieSceneEvaluationTree `json:"serialized_data"`
+}
+
+// https://github.com/EpicGames/UnrealEngine/blob/4.26/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationField.h
+type FEntityAndMetaDataIndex struct {
+	EntityIndex   int32 `json:"entity_index"`
+	MetaDataIndex int32 `json:"meta_data_index"`
+}
+
+func (parser *PakParser) ReadFMovieSceneEvaluationFieldEntityTree() *FMovieSceneEvaluationFieldEntityTree {
+	return &FMovieSceneEvaluationFieldEntityTree{
+		SerializedData: parser.ReadFMovieSceneEvaluationTree(func() interface{} {
+			return &FEntityAndMetaDataIndex{
+				EntityIndex:   parser.ReadInt32(),
+				MetaDataIndex: parser.ReadInt32(),
+			}
+		}),
+	}
+}
@@ -0,0 +1,46 @@
+package parser
+
+import "context"
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTrack.h
+type FMovieSceneEvaluationTrack struct {
+	ObjectBindingID    *FGuid                             `json:"object_binding_id"`
+	EvaluationPriority uint16                             `json:"evaluation_priority"`
+	EvaluationMethod   uint8                              `json:"evaluation_method"`
+	Segments           []*FMovieSceneSegment              `json:"segments"`
+	EvaluationTree     *FSectionEvaluationDataTree        `json:"evaluation_tree"`
+	ChildTemplates     []*FMovieSceneEvalTemplatePtr      `json:"child_templates"`
+	TrackTemplate      *FMovieSceneTrackImplementationPtr `json:"track_template"`
+	EvaluationGroup    string                             `json:"evaluation_group"`
+	EvaluateInPreroll  bool                               `json:"evaluate_in_preroll"`
+	EvaluateInPostroll bool                               `json:"evaluate_in_postroll"`
+}
+
+func (parser *PakParser) ReadFMovieSceneEvaluationTrack(ctx context.Context, uAsset *FPackageFileSummary) *FMovieSceneEvaluationTrack {
+	track := &FMovieSceneEvaluationTrack{
+		ObjectBindingID:    parser.ReadFGuid(),
+		EvaluationPriority: parser.ReadUint16(),
+		EvaluationMethod:   parser.Read(1)[0],
+	}
+
+	segmentCount := parser.ReadUint32()
+	track.Segments = make([]*FMovieSceneSegment, segmentCount)
+	for i := uint32(0); i < segmentCount; i++ {
+		track.Segments[i] = parser.ReadFMovieSceneSegment(ctx, uAsset)
+	}
+
+	track.EvaluationTree = parser.ReadFSectionEvaluationDataTree(ctx, uAsset)
+
+	templateCount := parser.ReadUint32()
+	track.ChildTemplates = make([]*FMovieSceneEvalTemplatePtr, templateCount)
+	for i := uint32(0); i < templateCount; i++ {
+		track.ChildTemplates[i] = parser.ReadFMovieSceneEvalTemplatePtr(ctx, uAsset)
+	}
+
+	track.TrackTemplate = parser.ReadFMovieSceneTrackImplementationPtr(ctx, uAsset)
+	track.EvaluationGroup = parser.ReadFName(uAsset.Names)
+	track.EvaluateInPreroll = parser.ReadInt32() != 0
+	track.EvaluateInPostroll = parser.ReadInt32() != 0
+
+	return track
+}
@@ -0,0 +1,81 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTree.h
+type FMovieSceneEvaluationTree struct {
+	RootNode   *FMovieSceneEvaluationTreeNode `json:"root_node"`
+	ChildNodes *TEvaluationTreeEntryContainer `json:"child_nodes"`
+	Data       *TEvaluationTreeEntryContainer `json:"data"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTree.h
+type FMovieSceneEvaluationTreeNode struct {
+	Range      *TRange                              `json:"range"`
+	Parent     *FMovieSceneEvaluationTreeNodeHandle `json:"parent"`
+	ChildrenID int32                                `json:"children_id"`
+	DataID     int32                                `json:"data_id"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTree.h
+type FMovieSceneEvaluationTreeNodeHandle struct {
+	ChildrenHandle int32 `json:"children_handle"`
+	Index          int32 `json:"index"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTreeEntryContainer.h
+type TEvaluationTreeEntryContainer struct {
+	Entries []*FEvaluationTreeEntry `json:"entries"`
+	Items   []interface{}           `json:"items"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTreeEntryContainer.h
+type FEvaluationTreeEntry struct {
+	StartIndex int32 `json:"start_index"`
+	Size       int32 `json:"size"`
+	Capacity   int32 `json:"capacity"`
+}
+
+// ReadFMovieSceneEvaluationTree reads a TMovieSceneEvaluationTree using readItem for each data entry
+func (parser *PakParser) ReadFMovieSceneEvaluationTree(readItem func() interface{}) *FMovieSceneEvaluationTree {
+	return &FMovieSceneEvaluationTree{
+		RootNode: parser.ReadFMovieSceneEvaluationTreeNode(),
+		ChildNodes: parser.ReadTEvaluationTreeEntryContainer(func() interface{} {
+			return parser.ReadFMovieSceneEvaluationTreeNode()
+		}),
+		Data: parser.ReadTEvaluationTreeEntryContainer(readItem),
+	}
+}
+
+func (parser *PakParser) ReadFMovieSceneEvaluationTreeNode() *FMovieSceneEvaluationTreeNode {
+	return &FMovieSceneEvaluationTreeNode{
+		Range: parser.ReadTRange("int32"),
+		Parent: &FMovieSceneEvaluationTreeNodeHandle{
+			ChildrenHandle: parser.ReadInt32(),
+			Index:          parser.ReadInt32(),
+		},
+		ChildrenID: parser.ReadInt32(),
+		DataID:     parser.ReadInt32(),
+	}
+}
+
+func (parser *PakParser) ReadTEvaluationTreeEntryContainer(readItem func() interface{}) *TEvaluationTreeEntryContainer {
+	entryCount := parser.ReadUint32()
+	entries := make([]*FEvaluationTreeEntry, entryCount)
+	for i := uint32(0); i < entryCount; i++ {
+		entries[i] = &FEvaluationTreeEntry{
+			StartIndex: parser.ReadInt32(),
+			Size:       parser.ReadInt32(),
+			Capacity:   parser.ReadInt32(),
+		}
+	}
+
+	itemCount := parser.ReadUint32()
+	items := make([]interface{}, itemCount)
+	for i := uint32(0); i < itemCount; i++ {
+		items[i] = readItem()
+	}
+
+	return &TEvaluationTreeEntryContainer{
+		Entries: entries,
+		Items:   items,
+	}
+}
@@ -0,0 +1,14 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieSceneTracks/Public/Sections/MovieSceneEventSection.h
+type FMovieSceneEventParameters struct {
+	StructType  *FSoftObjectPath `json:"struct_type"`
+	StructBytes []byte           `json:"struct_bytes"`
+}
+
+func (parser *PakParser) ReadFMovieSceneEventParameters(names []*FNameEntrySerialized) *FMovieSceneEventParameters {
+	return &FMovieSceneEventParameters{
+		StructType:  parser.ReadFSoftObjectPath(names),
+		StructBytes: parser.Read(parser.ReadInt32()),
+	}
+}
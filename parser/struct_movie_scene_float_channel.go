@@ -11,5 +11,59 @@ type FMovieSceneFloatChannel struct {
 }
 
 func (parser *PakParser) ReadFMovieSceneFloatChannel() *FMovieSceneFloatChannel {
-	panic("ReadFMovieSceneFloatChannel is not implemented")
+	channel := &FMovieSceneFloatChannel{
+		PreInfinityExtrap:  parser.Read(1)[0],
+		PostInfinityExtrap: parser.Read(1)[0],
+	}
+
+	// Times and values are bulk serialized, each array is prefixed by the in-memory size of an element
+	timeSize := parser.ReadInt32()
+	timeCount := parser.ReadInt32()
+	channel.Times = make([]FFrameNumber, timeCount)
+	for i := int32(0); i < timeCount; i++ {
+		channel.Times[i] = *parser.ReadFFrameNumber()
+		parser.skipBulkElementPadding(timeSize, 4)
+	}
+
+	valueSize := parser.ReadInt32()
+	valueCount := parser.ReadInt32()
+	channel.Values = make([]FMovieSceneFloatValue, valueCount)
+	for i := int32(0); i < valueCount; i++ {
+		channel.Values[i] = *parser.readBulkFMovieSceneFloatValue(valueSize)
+	}
+
+	channel.DefaultValue = parser.ReadFloat32()
+	channel.HasDefaultValue = parser.ReadInt32() != 0
+
+	return channel
+}
+
+// readBulkFMovieSceneFloatValue reads a value using its in-memory layout, which includes alignment padding
+func (parser *PakParser) readBulkFMovieSceneFloatValue(elementSize int32) *FMovieSceneFloatValue {
+	value := parser.ReadFloat32()
+
+	tangent := &FMovieSceneTangentData{
+		ArriveTangent: parser.ReadFloat32(),
+		LeaveTangent:  parser.ReadFloat32(),
+	}
+	tangent.TangentWeightMode = parser.Read(4)[0]
+	tangent.ArriveTangentWeight = parser.ReadFloat32()
+	tangent.LeaveTangentWeight = parser.ReadFloat32()
+
+	result := &FMovieSceneFloatValue{
+		Value:       value,
+		InterpMode:  parser.Read(1)[0],
+		TangentMode: parser.Read(1)[0],
+		Tangent:     tangent,
+	}
+
+	parser.skipBulkElementPadding(elementSize, 26)
+
+	return result
+}
+
+func (parser *PakParser) skipBulkElementPadding(elementSize int32, read int32) {
+	if elementSize > read {
+		parser.Read(elementSize - read)
+	}
 }
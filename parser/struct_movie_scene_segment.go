@@ -0,0 +1,27 @@
+package parser
+
+import "context"
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneSegment.h
+type FMovieSceneSegment struct {
+	Range      *TRange                       `json:"range"`
+	ID         *FMovieSceneSegmentIdentifier `json:"id"`
+	AllowEmpty bool                          `json:"allow_empty"`
+	Impls      [][]*FPropertyTag             `json:"impls"`
+}
+
+func (parser *PakParser) ReadFMovieSceneSegment(ctx context.Context, uAsset *FPackageFileSummary) *FMovieSceneSegment {
+	segment := &FMovieSceneSegment{
+		Range:      parser.ReadTRange("int32"),
+		ID:         parser.ReadFMovieSceneSegmentIdentifier(),
+		AllowEmpty: parser.ReadInt32() != 0,
+	}
+
+	implCount := parser.ReadUint32()
+	segment.Impls = make([][]*FPropertyTag, implCount)
+	for i := uint32(0); i < implCount; i++ {
+		segment.Impls[i] = parser.ReadFSectionEvaluationData(ctx, uAsset)
+	}
+
+	return segment
+}
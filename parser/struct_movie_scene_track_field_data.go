@@ -0,0 +1,14 @@
+package parser
+
+// https://github.com/EpicGames/UnrealEngine/blob/4.26/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTemplate.h
+type FMovieSceneTrackFieldData struct {
+	Field *FMovieSceneEvaluationTree `json:"field"`
+}
+
+func (parser *PakParser) ReadFMovieSceneTrackFieldData() *FMovieSceneTrackFieldData {
+	return &FMovieSceneTrackFieldData{
+		Field: parser.ReadFMovieSceneEvaluationTree(func() interface{} {
+			return parser.ReadFMovieSceneTrackIdentifier()
+		}),
+	}
+}
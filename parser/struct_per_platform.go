@@ -0,0 +1,49 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/CoreUObject/Public/UObject/PerPlatformProperties.h
+type FPerPlatformFloat struct {
+	Cooked      bool               `json:"cooked"`
+	Default     float32            `json:"default"`
+	PerPlatform map[string]float32 `json:"per_platform,omitempty"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/CoreUObject/Public/UObject/PerPlatformProperties.h
+type FPerPlatformInt struct {
+	Cooked      bool             `json:"cooked"`
+	Default     int32            `json:"default"`
+	PerPlatform map[string]int32 `json:"per_platform,omitempty"`
+}
+
+func (parser *PakParser) ReadFPerPlatformFloat(names []*FNameEntrySerialized) *FPerPlatformFloat {
+	result := &FPerPlatformFloat{
+		Cooked:  parser.ReadInt32() != 0,
+		Default: parser.ReadFloat32(),
+	}
+
+	if !result.Cooked {
+		count := parser.ReadUint32()
+		result.PerPlatform = make(map[string]float32, count)
+		for i := uint32(0); i < count; i++ {
+			result.PerPlatform[parser.ReadFName(names)] = parser.ReadFloat32()
+		}
+	}
+
+	return result
+}
+
+func (parser *PakParser) ReadFPerPlatformInt(names []*FNameEntrySerialized) *FPerPlatformInt {
+	result := &FPerPlatformInt{
+		Cooked:  parser.ReadInt32() != 0,
+		Default: parser.ReadInt32(),
+	}
+
+	if !result.Cooked {
+		count := parser.ReadUint32()
+		result.PerPlatform = make(map[string]int32, count)
+		for i := uint32(0); i < count; i++ {
+			result.PerPlatform[parser.ReadFName(names)] = parser.ReadInt32()
+		}
+	}
+
+	return result
+}
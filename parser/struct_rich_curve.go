@@ -0,0 +1,111 @@
+package parser
+
+import "strings"
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Curves/RichCurve.h
+type FRichCurve struct {
+	PreInfinityExtrap  string           `json:"pre_infinity_extrap"`
+	PostInfinityExtrap string           `json:"post_infinity_extrap"`
+	DefaultValue       *float32         `json:"default_value"`
+	Keys               []*FRichCurveKey `json:"keys"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Curves/SimpleCurve.h
+type FSimpleCurve struct {
+	PreInfinityExtrap  string             `json:"pre_infinity_extrap"`
+	PostInfinityExtrap string             `json:"post_infinity_extrap"`
+	DefaultValue       *float32           `json:"default_value"`
+	InterpMode         string             `json:"interp_mode"`
+	Keys               []*FSimpleCurveKey `json:"keys"`
+}
+
+// NewFRichCurve builds a curve from its tagged properties, only the keys of curves have a native layout
+func NewFRichCurve(properties []*FPropertyTag) *FRichCurve {
+	curve := &FRichCurve{
+		PreInfinityExtrap:  enumProperty(properties, "PreInfinityExtrap", "RCCE_Constant"),
+		PostInfinityExtrap: enumProperty(properties, "PostInfinityExtrap", "RCCE_Constant"),
+		DefaultValue:       defaultValueProperty(properties),
+		Keys:               make([]*FRichCurveKey, 0),
+	}
+
+	for _, value := range structArrayProperty(properties, "Keys") {
+		if key, ok := value.(*FRichCurveKey); ok {
+			curve.Keys = append(curve.Keys, key)
+		}
+	}
+
+	return curve
+}
+
+// NewFSimpleCurve builds a curve from its tagged properties
+func NewFSimpleCurve(properties []*FPropertyTag) *FSimpleCurve {
+	curve := &FSimpleCurve{
+		PreInfinityExtrap:  enumProperty(properties, "PreInfinityExtrap", "RCCE_Constant"),
+		PostInfinityExtrap: enumProperty(properties, "PostInfinityExtrap", "RCCE_Constant"),
+		DefaultValue:       defaultValueProperty(properties),
+		InterpMode:         enumProperty(properties, "InterpMode", "RCIM_Linear"),
+		Keys:               make([]*FSimpleCurveKey, 0),
+	}
+
+	for _, value := range structArrayProperty(properties, "Keys") {
+		if key, ok := value.(*FSimpleCurveKey); ok {
+			curve.Keys = append(curve.Keys, key)
+		}
+	}
+
+	return curve
+}
+
+// enumProperty returns the enum value name of the property, fallback if the property is missing
+func enumProperty(properties []*FPropertyTag, name string, fallback string) string {
+	if property := findProperty(properties, name); property != nil {
+		if value, ok := property.Tag.(string); ok {
+			value = strings.Trim(value, "\x00")
+
+			// Byte enum values are prefixed with the enum name
+			if index := strings.LastIndex(value, "::"); index >= 0 {
+				value = value[index+2:]
+			}
+
+			return value
+		}
+	}
+
+	return fallback
+}
+
+// defaultValueProperty returns the default value of a curve, nil if it is unset
+func defaultValueProperty(properties []*FPropertyTag) *float32 {
+	if property := findProperty(properties, "DefaultValue"); property != nil {
+		// MAX_flt marks an unset default value
+		if value, ok := property.Tag.(float32); ok && value != 3.402823466e+38 {
+			return &value
+		}
+	}
+
+	return nil
+}
+
+// structArrayProperty returns the values of a native struct array property
+func structArrayProperty(properties []*FPropertyTag, name string) []interface{} {
+	property := findProperty(properties, name)
+	if property == nil {
+		return nil
+	}
+
+	elements, ok := property.Tag.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		if arrayStruct, ok := element.(*ArrayStructProperty); ok {
+			if structType, ok := arrayStruct.Properties.(*StructType); ok {
+				values = append(values, structType.Value)
+			}
+		}
+	}
+
+	return values
+}
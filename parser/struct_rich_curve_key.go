@@ -6,6 +6,7 @@ type FRichCurveKey struct {
 	TangentMode         uint8   `json:"tangent_mode"`
 	TangentWeightMode   uint8   `json:"tangent_weight_mode"`
 	Time                float32 `json:"time"`
+	Value               float32 `json:"value"`
 	ArriveTangent       float32 `json:"arrive_tangent"`
 	ArriveTangentWeight float32 `json:"arrive_tangent_weight"`
 	LeaveTangent        float32 `json:"leave_tangent"`
@@ -18,6 +19,7 @@ func (parser *PakParser) ReadFRichCurveKey() *FRichCurveKey {
 		TangentMode:         parser.Read(1)[0],
 		TangentWeightMode:   parser.Read(1)[0],
 		Time:                parser.ReadFloat32(),
+		Value:               parser.ReadFloat32(),
 		ArriveTangent:       parser.ReadFloat32(),
 		ArriveTangentWeight: parser.ReadFloat32(),
 		LeaveTangent:        parser.ReadFloat32(),
@@ -0,0 +1,22 @@
+package parser
+
+import "context"
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneEvaluationTrack.h
+type FSectionEvaluationDataTree struct {
+	Tree *FMovieSceneEvaluationTree `json:"tree"`
+}
+
+// ReadFSectionEvaluationData reads FSectionEvaluationData, which is serialized as tagged properties
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/MovieScene/Public/Evaluation/MovieSceneSegment.h
+func (parser *PakParser) ReadFSectionEvaluationData(ctx context.Context, uAsset *FPackageFileSummary) []*FPropertyTag {
+	return parser.ReadFPropertyTagLoop(ctx, uAsset)
+}
+
+func (parser *PakParser) ReadFSectionEvaluationDataTree(ctx context.Context, uAsset *FPackageFileSummary) *FSectionEvaluationDataTree {
+	return &FSectionEvaluationDataTree{
+		Tree: parser.ReadFMovieSceneEvaluationTree(func() interface{} {
+			return parser.ReadFSectionEvaluationData(ctx, uAsset)
+		}),
+	}
+}
@@ -0,0 +1,14 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Curves/SimpleCurve.h
+type FSimpleCurveKey struct {
+	Time  float32 `json:"time"`
+	Value float32 `json:"value"`
+}
+
+func (parser *PakParser) ReadFSimpleCurveKey() *FSimpleCurveKey {
+	return &FSimpleCurveKey{
+		Time:  parser.ReadFloat32(),
+		Value: parser.ReadFloat32(),
+	}
+}
@@ -0,0 +1,39 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Engine/SkeletalMeshSampling.h
+type FSkeletalMeshSamplingLODBuiltData struct {
+	AreaWeightedTriangleSampler *FWeightedRandomSampler `json:"area_weighted_triangle_sampler"`
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Public/WeightedRandomSampler.h
+type FWeightedRandomSampler struct {
+	Prob        []float32 `json:"prob"`
+	Alias       []int32   `json:"alias"`
+	TotalWeight float32   `json:"total_weight"`
+}
+
+func (parser *PakParser) ReadFSkeletalMeshSamplingLODBuiltData() *FSkeletalMeshSamplingLODBuiltData {
+	return &FSkeletalMeshSamplingLODBuiltData{
+		AreaWeightedTriangleSampler: parser.ReadFWeightedRandomSampler(),
+	}
+}
+
+func (parser *PakParser) ReadFWeightedRandomSampler() *FWeightedRandomSampler {
+	probCount := parser.ReadUint32()
+	prob := make([]float32, probCount)
+	for i := uint32(0); i < probCount; i++ {
+		prob[i] = parser.ReadFloat32()
+	}
+
+	aliasCount := parser.ReadUint32()
+	alias := make([]int32, aliasCount)
+	for i := uint32(0); i < aliasCount; i++ {
+		alias[i] = parser.ReadInt32()
+	}
+
+	return &FWeightedRandomSampler{
+		Prob:        prob,
+		Alias:       alias,
+		TotalWeight: parser.ReadFloat32(),
+	}
+}
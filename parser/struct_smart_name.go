@@ -0,0 +1,12 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Engine/Classes/Animation/SmartName.h
+type FSmartName struct {
+	DisplayName string `json:"display_name"`
+}
+
+func (parser *PakParser) ReadFSmartName(names []*FNameEntrySerialized) *FSmartName {
+	return &FSmartName{
+		DisplayName: parser.ReadFName(names),
+	}
+}
@@ -0,0 +1,9 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/CoreUObject/Public/UObject/SoftObjectPath.h
+func (parser *PakParser) ReadFSoftObjectPath(names []*FNameEntrySerialized) *FSoftObjectPath {
+	return &FSoftObjectPath{
+		AssetPathName: parser.ReadFName(names),
+		SubPath:       parser.ReadString(),
+	}
+}
@@ -0,0 +1,16 @@
+package parser
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Math/TransformNonVectorized.h
+type FTransform struct {
+	Rotation    *FQuat   `json:"rotation"`
+	Translation *FVector `json:"translation"`
+	Scale3D     *FVector `json:"scale_3d"`
+}
+
+func (parser *PakParser) ReadFTransform() *FTransform {
+	return &FTransform{
+		Rotation:    parser.ReadFQuat(),
+		Translation: parser.ReadFVector(),
+		Scale3D:     parser.ReadFVector(),
+	}
+}
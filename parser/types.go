@@ -2,7 +2,7 @@ package parser
 
 import (
 	"encoding/json"
-	"github.com/spf13/viper"
+	"strings"
 )
 
 var mapPropertyTypeOverrides = map[string]*MapProperty{
@@ -36,8 +36,9 @@ var mapPropertyTypeOverrides = map[string]*MapProperty{
 	},
 }
 
+// RegisterMapPropertyOverride registers a built-in override included in every default registry created afterwards
 func RegisterMapPropertyOverride(name string, override *MapProperty) {
-	mapPropertyTypeOverrides[name] = override
+	builtins.RegisterMapPropertyOverride(name, override)
 }
 
 type PakEntrySet struct {
@@ -46,6 +47,35 @@ type PakEntrySet struct {
 	Exports      []PakExportSet       `json:"exports"`
 }
 
+// MarshalJSONWithOptions marshals the entry, including the optional fields of its summary and exports selected by the options
+func (set *PakEntrySet) MarshalJSONWithOptions(options *SerializeOptions) ([]byte, error) {
+	type exportSet struct {
+		Export withOptions `json:"export"`
+		Data   *ExportData `json:"data"`
+	}
+
+	ex := &struct {
+		ExportRecord *FPakEntry  `json:"export_record"`
+		Summary      withOptions `json:"summary"`
+		Exports      []exportSet `json:"exports"`
+	}{
+		ExportRecord: set.ExportRecord,
+		Summary:      withOptions{value: set.Summary, options: options},
+	}
+
+	if set.Exports != nil {
+		ex.Exports = make([]exportSet, len(set.Exports))
+		for i, export := range set.Exports {
+			ex.Exports[i] = exportSet{
+				Export: withOptions{value: export.Export, options: options},
+				Data:   export.Data,
+			}
+		}
+	}
+
+	return json.Marshal(&ex)
+}
+
 type PakExportSet struct {
 	Export *FObjectExport `json:"export"`
 	Data   *ExportData    `json:"data"`
@@ -58,6 +88,7 @@ type FPakInfo struct {
 	IndexSize       uint64 `json:"index_size"`
 	IndexSHA1Hash   []byte `json:"index_sha_1_hash"`
 	CompressionType string `json:"compression_type"`
+	EncryptedIndex  bool   `json:"encrypted_index"`
 }
 
 type FPakIndex struct {
@@ -83,6 +114,9 @@ type FPakEntry struct {
 
 	IsEncrypted          bool   `json:"is_encrypted"`
 	CompressionBlockSize uint32 `json:"compression_block_size"`
+
+	// dataOffset caches the offset of the data of the file, once its header was read
+	dataOffset int64
 }
 
 type FPakCompressedBlock struct {
@@ -101,6 +135,59 @@ type FNameEntrySerialized struct {
 	CasePreservingHash    uint16 `json:"case_preserving_hash"`
 }
 
+// SerializeOptions controls which optional fields are included when marshaling to JSON
+type SerializeOptions struct {
+	// WithIndex includes resolved FPackageIndex references of imports and exports
+	WithIndex bool
+
+	// WithNames includes the name table of package summaries
+	WithNames bool
+}
+
+// WithSerializeOptions wraps entries, summaries, imports, exports and package indexes, so they are marshaled using the options.
+// Options are not applied to references within decoded export data.
+func WithSerializeOptions(value interface{}, options *SerializeOptions) json.Marshaler {
+	return withOptions{value: value, options: options}
+}
+
+type withOptions struct {
+	value   interface{}
+	options *SerializeOptions
+}
+
+func (w withOptions) MarshalJSON() ([]byte, error) {
+	switch v := w.value.(type) {
+	case *PakEntrySet:
+		if v != nil {
+			return v.MarshalJSONWithOptions(w.options)
+		}
+	case []*PakEntrySet:
+		entries := make([]withOptions, len(v))
+		for i, entry := range v {
+			entries[i] = withOptions{value: entry, options: w.options}
+		}
+		return json.Marshal(entries)
+	case *FPackageFileSummary:
+		if v != nil {
+			return v.MarshalJSONWithOptions(w.options)
+		}
+	case *FObjectImport:
+		if v != nil {
+			return v.MarshalJSONWithOptions(w.options)
+		}
+	case *FObjectExport:
+		if v != nil {
+			return v.MarshalJSONWithOptions(w.options)
+		}
+	case *FPackageIndex:
+		if v != nil {
+			return v.MarshalJSONWithOptions(w.options)
+		}
+	}
+
+	return json.Marshal(w.value)
+}
+
 type FObjectImport struct {
 	ClassPackage string         `json:"class_package"`
 	ClassName    string         `json:"class_name"`
@@ -110,12 +197,17 @@ type FObjectImport struct {
 }
 
 func (m *FObjectImport) MarshalJSON() ([]byte, error) {
+	return m.MarshalJSONWithOptions(nil)
+}
+
+// MarshalJSONWithOptions marshals the import, including the outer package if the options contain WithIndex
+func (m *FObjectImport) MarshalJSONWithOptions(options *SerializeOptions) ([]byte, error) {
 	ex := &struct {
-		ClassPackage string         `json:"class_package"`
-		ClassName    string         `json:"class_name"`
-		OuterIndex   int32          `json:"outer_index"`
-		ObjectName   string         `json:"object_name"`
-		OuterPackage *FPackageIndex `json:"outer_package"`
+		ClassPackage string       `json:"class_package"`
+		ClassName    string       `json:"class_name"`
+		OuterIndex   int32        `json:"outer_index"`
+		ObjectName   string       `json:"object_name"`
+		OuterPackage *withOptions `json:"outer_package"`
 	}{
 		ClassPackage: m.ClassPackage,
 		ClassName:    m.ClassName,
@@ -123,8 +215,8 @@ func (m *FObjectImport) MarshalJSON() ([]byte, error) {
 		ObjectName:   m.ObjectName,
 	}
 
-	if viper.GetBool("with-index") {
-		ex.OuterPackage = m.OuterPackage
+	if options != nil && options.WithIndex {
+		ex.OuterPackage = &withOptions{value: m.OuterPackage, options: options}
 	}
 
 	return json.Marshal(&ex)
@@ -135,6 +227,19 @@ type FPackageIndex struct {
 	Reference interface{} `json:"reference"`
 }
 
+// MarshalJSONWithOptions marshals the index, marshaling the referenced import or export using the options
+func (index *FPackageIndex) MarshalJSONWithOptions(options *SerializeOptions) ([]byte, error) {
+	ex := &struct {
+		Index     int32       `json:"index"`
+		Reference withOptions `json:"reference"`
+	}{
+		Index:     index.Index,
+		Reference: withOptions{value: index.Reference, options: options},
+	}
+
+	return json.Marshal(&ex)
+}
+
 type FObjectExport struct {
 	ClassIndex                                   *FPackageIndex `json:"class_index"`
 	SuperIndex                                   *FPackageIndex `json:"super_index"`
@@ -152,34 +257,49 @@ type FObjectExport struct {
 	NotAlwaysLoadedForEditorGame                 bool           `json:"not_always_loaded_for_editor_game"`
 	IsAsset                                      bool           `json:"is_asset"`
 	FirstExportDependency                        int32          `json:"first_export_dependency"`
-	SerializationBeforeSerializationDependencies bool           `json:"serialization_before_serialization_dependencies"`
-	CreateBeforeSerializationDependencies        bool           `json:"create_before_serialization_dependencies"`
-	SerializationBeforeCreateDependencies        bool           `json:"serialization_before_create_dependencies"`
-	CreateBeforeCreateDependencies               bool           `json:"create_before_create_dependencies"`
+	SerializationBeforeSerializationDependencies int32          `json:"serialization_before_serialization_dependencies"`
+	CreateBeforeSerializationDependencies        int32          `json:"create_before_serialization_dependencies"`
+	SerializationBeforeCreateDependencies        int32          `json:"serialization_before_create_dependencies"`
+	CreateBeforeCreateDependencies               int32          `json:"create_before_create_dependencies"`
+
+	// Preload dependencies of the export, split by the kind of dependency
+	SerializationBeforeSerialization []*FPackageIndex `json:"serialization_before_serialization"`
+	CreateBeforeSerialization        []*FPackageIndex `json:"create_before_serialization"`
+	SerializationBeforeCreate        []*FPackageIndex `json:"serialization_before_create"`
+	CreateBeforeCreate               []*FPackageIndex `json:"create_before_create"`
 }
 
 func (m *FObjectExport) MarshalJSON() ([]byte, error) {
+	return m.MarshalJSONWithOptions(nil)
+}
+
+// MarshalJSONWithOptions marshals the export, including its class, super, template and outer if the options contain WithIndex
+func (m *FObjectExport) MarshalJSONWithOptions(options *SerializeOptions) ([]byte, error) {
 	ex := &struct {
-		ClassIndex                                   *FPackageIndex `json:"class_index"`
-		SuperIndex                                   *FPackageIndex `json:"super_index"`
-		TemplateIndex                                *FPackageIndex `json:"template_index"`
-		OuterIndex                                   *FPackageIndex `json:"outer_index"`
-		ObjectName                                   string         `json:"object_name"`
-		Save                                         uint32         `json:"save"`
-		SerialSize                                   int64          `json:"serial_size"`
-		SerialOffset                                 int64          `json:"serial_offset"`
-		ForcedExport                                 bool           `json:"forced_export"`
-		NotForClient                                 bool           `json:"not_for_client"`
-		NotForServer                                 bool           `json:"not_for_server"`
-		PackageGuid                                  *FGuid         `json:"package_guid"`
-		PackageFlags                                 uint32         `json:"package_flags"`
-		NotAlwaysLoadedForEditorGame                 bool           `json:"not_always_loaded_for_editor_game"`
-		IsAsset                                      bool           `json:"is_asset"`
-		FirstExportDependency                        int32          `json:"first_export_dependency"`
-		SerializationBeforeSerializationDependencies bool           `json:"serialization_before_serialization_dependencies"`
-		CreateBeforeSerializationDependencies        bool           `json:"create_before_serialization_dependencies"`
-		SerializationBeforeCreateDependencies        bool           `json:"serialization_before_create_dependencies"`
-		CreateBeforeCreateDependencies               bool           `json:"create_before_create_dependencies"`
+		ClassIndex                                   *withOptions `json:"class_index"`
+		SuperIndex                                   *withOptions `json:"super_index"`
+		TemplateIndex                                *withOptions `json:"template_index"`
+		OuterIndex                                   *withOptions `json:"outer_index"`
+		ObjectName                                   string       `json:"object_name"`
+		Save                                         uint32       `json:"save"`
+		SerialSize                                   int64        `json:"serial_size"`
+		SerialOffset                                 int64        `json:"serial_offset"`
+		ForcedExport                                 bool         `json:"forced_export"`
+		NotForClient                                 bool         `json:"not_for_client"`
+		NotForServer                                 bool         `json:"not_for_server"`
+		PackageGuid                                  *FGuid       `json:"package_guid"`
+		PackageFlags                                 uint32       `json:"package_flags"`
+		NotAlwaysLoadedForEditorGame                 bool         `json:"not_always_loaded_for_editor_game"`
+		IsAsset                                      bool         `json:"is_asset"`
+		FirstExportDependency                        int32        `json:"first_export_dependency"`
+		SerializationBeforeSerializationDependencies int32        `json:"serialization_before_serialization_dependencies"`
+		CreateBeforeSerializationDependencies        int32        `json:"create_before_serialization_dependencies"`
+		SerializationBeforeCreateDependencies        int32        `json:"serialization_before_create_dependencies"`
+		CreateBeforeCreateDependencies               int32        `json:"create_before_create_dependencies"`
+		SerializationBeforeSerialization             []string     `json:"serialization_before_serialization,omitempty"`
+		CreateBeforeSerialization                    []string     `json:"create_before_serialization,omitempty"`
+		SerializationBeforeCreate                    []string     `json:"serialization_before_create,omitempty"`
+		CreateBeforeCreate                           []string     `json:"create_before_create,omitempty"`
 	}{
 		ObjectName:                   m.ObjectName,
 		Save:                         m.Save,
@@ -197,13 +317,17 @@ func (m *FObjectExport) MarshalJSON() ([]byte, error) {
 		CreateBeforeSerializationDependencies:        m.CreateBeforeSerializationDependencies,
 		SerializationBeforeCreateDependencies:        m.SerializationBeforeCreateDependencies,
 		CreateBeforeCreateDependencies:               m.CreateBeforeCreateDependencies,
+		SerializationBeforeSerialization:             packageIndexNames(m.SerializationBeforeSerialization),
+		CreateBeforeSerialization:                    packageIndexNames(m.CreateBeforeSerialization),
+		SerializationBeforeCreate:                    packageIndexNames(m.SerializationBeforeCreate),
+		CreateBeforeCreate:                           packageIndexNames(m.CreateBeforeCreate),
 	}
 
-	if viper.GetBool("with-index") {
-		ex.ClassIndex = m.ClassIndex
-		ex.SuperIndex = m.SuperIndex
-		ex.TemplateIndex = m.TemplateIndex
-		ex.OuterIndex = m.OuterIndex
+	if options != nil && options.WithIndex {
+		ex.ClassIndex = &withOptions{value: m.ClassIndex, options: options}
+		ex.SuperIndex = &withOptions{value: m.SuperIndex, options: options}
+		ex.TemplateIndex = &withOptions{value: m.TemplateIndex, options: options}
+		ex.OuterIndex = &withOptions{value: m.OuterIndex, options: options}
 	}
 
 	return json.Marshal(&ex)
@@ -217,10 +341,13 @@ type FPackageFileSummary struct {
 	LegacyUE3Version            int32                   `json:"legacy_ue_3_version"`
 	FileVersionUE4              int32                   `json:"file_version_ue_4"`
 	FileVersionLicenseeUE4      int32                   `json:"file_version_licensee_ue_4"`
+	Unversioned                 bool                    `json:"unversioned"`
+	CustomVersions              []*FCustomVersion       `json:"custom_versions"`
 	TotalHeaderSize             int32                   `json:"total_header_size"`
 	FolderName                  string                  `json:"folder_name"`
 	PackageFlags                uint32                  `json:"package_flags"`
 	NameOffset                  int32                   `json:"name_offset"`
+	LocalizationId              string                  `json:"localization_id"`
 	GatherableTextDataCount     int32                   `json:"gatherable_text_data_count"`
 	GatherableTextDataOffset    int32                   `json:"gatherable_text_data_offset"`
 	ExportOffset                int32                   `json:"export_offset"`
@@ -239,7 +366,7 @@ type FPackageFileSummary struct {
 	PackageSource               uint32                  `json:"package_source"`
 	AdditionalPackagesToCook    []string                `json:"additional_packages_to_cook"`
 	AssetRegistryDataOffset     int32                   `json:"asset_registry_data_offset"`
-	BulkDataStartOffset         int32                   `json:"bulk_data_start_offset"`
+	BulkDataStartOffset         int64                   `json:"bulk_data_start_offset"`
 	WorldTileInfoDataOffset     int32                   `json:"world_tile_info_data_offset"`
 	ChunkIds                    []int32                 `json:"chunk_ids"`
 	PreloadDependencyCount      int32                   `json:"preload_dependency_count"`
@@ -247,9 +374,22 @@ type FPackageFileSummary struct {
 	Names                       []*FNameEntrySerialized `json:"names"`
 	Imports                     []*FObjectImport        `json:"imports"`
 	Exports                     []*FObjectExport        `json:"exports"`
+	DependsMap                  [][]*FPackageIndex      `json:"depends_map"`
+	SoftPackageReferences       []string                `json:"soft_package_references"`
+	PreloadDependencies         []*FPackageIndex        `json:"preload_dependencies"`
+	GatherableTextData          []*FGatherableTextData  `json:"gatherable_text_data"`
+	SearchableNames             []*SearchableNames      `json:"searchable_names"`
+	Thumbnails                  []*FObjectThumbnail     `json:"thumbnails"`
+	AssetRegistryData           []*AssetRegistryObject  `json:"asset_registry_data"`
+	WorldTileInfo               *FWorldTileInfo         `json:"world_tile_info"`
 }
 
 func (m *FPackageFileSummary) MarshalJSON() ([]byte, error) {
+	return m.MarshalJSONWithOptions(nil)
+}
+
+// MarshalJSONWithOptions marshals the summary, passing the options on to its imports and exports
+func (m *FPackageFileSummary) MarshalJSONWithOptions(options *SerializeOptions) ([]byte, error) {
 	ex := &struct {
 		Record                      *FPakEntry              `json:"record"`
 		Tag                         int32                   `json:"tag"`
@@ -257,10 +397,13 @@ func (m *FPackageFileSummary) MarshalJSON() ([]byte, error) {
 		LegacyUE3Version            int32                   `json:"legacy_ue_3_version"`
 		FileVersionUE4              int32                   `json:"file_version_ue_4"`
 		FileVersionLicenseeUE4      int32                   `json:"file_version_licensee_ue_4"`
+		Unversioned                 bool                    `json:"unversioned"`
+		CustomVersions              []*FCustomVersion       `json:"custom_versions,omitempty"`
 		TotalHeaderSize             int32                   `json:"total_header_size"`
 		FolderName                  string                  `json:"folder_name"`
 		PackageFlags                uint32                  `json:"package_flags"`
 		NameOffset                  int32                   `json:"name_offset"`
+		LocalizationId              string                  `json:"localization_id,omitempty"`
 		GatherableTextDataCount     int32                   `json:"gatherable_text_data_count"`
 		GatherableTextDataOffset    int32                   `json:"gatherable_text_data_offset"`
 		ExportOffset                int32                   `json:"export_offset"`
@@ -279,14 +422,22 @@ func (m *FPackageFileSummary) MarshalJSON() ([]byte, error) {
 		PackageSource               uint32                  `json:"package_source"`
 		AdditionalPackagesToCook    []string                `json:"additional_packages_to_cook"`
 		AssetRegistryDataOffset     int32                   `json:"asset_registry_data_offset"`
-		BulkDataStartOffset         int32                   `json:"bulk_data_start_offset"`
+		BulkDataStartOffset         int64                   `json:"bulk_data_start_offset"`
 		WorldTileInfoDataOffset     int32                   `json:"world_tile_info_data_offset"`
 		ChunkIds                    []int32                 `json:"chunk_ids"`
 		PreloadDependencyCount      int32                   `json:"preload_dependency_count"`
 		PreloadDependencyOffset     int32                   `json:"preload_dependency_offset"`
 		Names                       []*FNameEntrySerialized `json:"names"`
-		Imports                     []*FObjectImport        `json:"imports"`
-		Exports                     []*FObjectExport        `json:"exports"`
+		Imports                     []withOptions           `json:"imports"`
+		Exports                     []withOptions           `json:"exports"`
+		DependsMap                  [][]string              `json:"depends_map,omitempty"`
+		SoftPackageReferences       []string                `json:"soft_package_references,omitempty"`
+		PreloadDependencies         []string                `json:"preload_dependencies,omitempty"`
+		GatherableTextData          []*FGatherableTextData  `json:"gatherable_text_data,omitempty"`
+		SearchableNames             map[string][]string     `json:"searchable_names,omitempty"`
+		Thumbnails                  []*FObjectThumbnail     `json:"thumbnails,omitempty"`
+		AssetRegistryData           []*AssetRegistryObject  `json:"asset_registry_data,omitempty"`
+		WorldTileInfo               *FWorldTileInfo         `json:"world_tile_info,omitempty"`
 	}{
 		Record:                      m.Record,
 		Tag:                         m.Tag,
@@ -294,10 +445,13 @@ func (m *FPackageFileSummary) MarshalJSON() ([]byte, error) {
 		LegacyUE3Version:            m.LegacyUE3Version,
 		FileVersionUE4:              m.FileVersionUE4,
 		FileVersionLicenseeUE4:      m.FileVersionLicenseeUE4,
+		Unversioned:                 m.Unversioned,
+		CustomVersions:              m.CustomVersions,
 		TotalHeaderSize:             m.TotalHeaderSize,
 		FolderName:                  m.FolderName,
 		PackageFlags:                m.PackageFlags,
 		NameOffset:                  m.NameOffset,
+		LocalizationId:              m.LocalizationId,
 		GatherableTextDataCount:     m.GatherableTextDataCount,
 		GatherableTextDataOffset:    m.GatherableTextDataOffset,
 		ExportOffset:                m.ExportOffset,
@@ -321,11 +475,46 @@ func (m *FPackageFileSummary) MarshalJSON() ([]byte, error) {
 		ChunkIds:                    m.ChunkIds,
 		PreloadDependencyCount:      m.PreloadDependencyCount,
 		PreloadDependencyOffset:     m.PreloadDependencyOffset,
-		Imports:                     m.Imports,
-		Exports:                     m.Exports,
+		SoftPackageReferences:       m.SoftPackageReferences,
+		PreloadDependencies:         packageIndexNames(m.PreloadDependencies),
+		GatherableTextData:          m.GatherableTextData,
+		Thumbnails:                  m.Thumbnails,
+		AssetRegistryData:           m.AssetRegistryData,
+		WorldTileInfo:               m.WorldTileInfo,
+	}
+
+	if len(m.DependsMap) > 0 {
+		ex.DependsMap = make([][]string, len(m.DependsMap))
+		for i, depends := range m.DependsMap {
+			ex.DependsMap[i] = packageIndexNames(depends)
+		}
+	}
+
+	if len(m.SearchableNames) > 0 {
+		ex.SearchableNames = make(map[string][]string, len(m.SearchableNames))
+		for _, entry := range m.SearchableNames {
+			if name := entry.Object.ObjectName(); name != nil {
+				key := strings.Trim(*name, "\x00")
+				ex.SearchableNames[key] = append(ex.SearchableNames[key], entry.Names...)
+			}
+		}
+	}
+
+	if m.Imports != nil {
+		ex.Imports = make([]withOptions, len(m.Imports))
+		for i, imp := range m.Imports {
+			ex.Imports[i] = withOptions{value: imp, options: options}
+		}
 	}
 
-	if viper.GetBool("with-names") {
+	if m.Exports != nil {
+		ex.Exports = make([]withOptions, len(m.Exports))
+		for i, export := range m.Exports {
+			ex.Exports[i] = withOptions{value: export, options: options}
+		}
+	}
+
+	if options != nil && options.WithNames {
 		ex.Names = m.Names
 	}
 
@@ -345,6 +534,7 @@ type FPropertyTag struct {
 	ArrayIndex   int32       `json:"array_index"`
 	PropertyGuid *FGuid      `json:"property_guid"`
 	Tag          interface{} `json:"tag"`
+	Raw          *RawData    `json:"raw,omitempty"`
 }
 
 type StructProperty struct {
@@ -365,6 +555,18 @@ type FEngineVersion struct {
 	Branch     string `json:"branch"`
 }
 
+// AtLeast reports whether the version is the provided major and minor version or newer
+func (version *FEngineVersion) AtLeast(major uint16, minor uint16) bool {
+	return version.Major > major || (version.Major == major && version.Minor >= minor)
+}
+
+// https://github.com/SatisfactoryModdingUE/UnrealEngine/blob/4.22-CSS/Engine/Source/Runtime/Core/Public/Serialization/CustomVersion.h
+type FCustomVersion struct {
+	Key          *FGuid `json:"key"`
+	Version      int32  `json:"version"`
+	FriendlyName string `json:"friendly_name,omitempty"`
+}
+
 type FGenerationInfo struct {
 	ExportCount int32 `json:"export_count"`
 	NameCount   int32 `json:"name_count"`
@@ -392,6 +594,10 @@ type FText struct {
 	Namespace    string `json:"namespace"`
 	Key          string `json:"key"`
 	SourceString string `json:"source_string"`
+	TableId      string `json:"table_id,omitempty"`
+
+	// LocalizedString is the translation of the culture set on the parser
+	LocalizedString string `json:"localized_string,omitempty"`
 }
 
 type FScriptDelegate struct {
@@ -412,18 +618,21 @@ type MapPropertyEntry struct {
 type ExportData struct {
 	Properties []*FPropertyTag `json:"properties"`
 	Data       interface{}     `json:"data"`
-}
+	Raw        *RawData        `json:"raw,omitempty"`
 
-type FPakEntryLocation struct {
-	Index int32 `json:"index"`
+	// Unresolved is set when no class resolver exists for the export, leaving all of its class data in Raw
+	Unresolved bool `json:"-"`
 }
 
-func (pakInfo *FPakInfo) HeaderSize() uint64 {
-	if pakInfo.Version < 8 {
-		return 53
-	}
+// RawData holds bytes that could not be decoded, together with the offset they were read from.
+// The offset is relative to the start of the (uncompressed) .uasset or .uexp file.
+type RawData struct {
+	Offset int64  `json:"offset"`
+	Data   []byte `json:"data"`
+}
 
-	return 50
+type FPakEntryLocation struct {
+	Index int32 `json:"index"`
 }
 
 func (index *FPackageIndex) ObjectName() *string {
@@ -442,6 +651,22 @@ func (index *FPackageIndex) ObjectName() *string {
 	return nil
 }
 
+// packageIndexNames returns the object names of the references, which unlike the references themselves are never cyclic
+func packageIndexNames(indexes []*FPackageIndex) []string {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(indexes))
+	for i, index := range indexes {
+		if name := index.ObjectName(); name != nil {
+			names[i] = strings.Trim(*name, "\x00")
+		}
+	}
+
+	return names
+}
+
 func (index *FPackageIndex) ClassName() *string {
 	classReference := index.Reference
 
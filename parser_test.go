@@ -31,7 +31,7 @@ func TestParseAllAsFiles(t *testing.T) {
 			panic(err)
 		}
 
-		p := parser.NewParser(file)
+		p := parser.NewParser(file, nil)
 		pak := p.Parse(context.Background())
 
 		summaries := make(map[string]*parser.FPackageFileSummary, 0)
@@ -86,7 +86,7 @@ func TestParseAllAsBytes(t *testing.T) {
 			Bytes: data,
 		}
 
-		p := parser.NewParser(reader)
+		p := parser.NewParser(reader, nil)
 		pak := p.Parse(context.Background())
 
 		summaries := make(map[string]*parser.FPackageFileSummary, 0)